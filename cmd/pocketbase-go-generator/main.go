@@ -18,71 +18,94 @@ func main() {
 	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
 
 	rootCmd := cmd.GetGenerateGoCommand(false, func(cmd *cobra.Command, args []string, generatorFlags *cmd.GeneratorFlags) {
-		if generatorFlags.DisableLogs {
-			zerolog.SetGlobalLevel(4)
-		} else {
-			zerolog.SetGlobalLevel(1)
-		}
+		collections := getCollections(generatorFlags)
 
-		pbCredentials := &credentials.Credentials{
-			Host:     generatorFlags.Host,
-			Email:    generatorFlags.Email,
-			Password: generatorFlags.Password,
-		}
+		var selectedCollections []*pocketbase_api.Collection
 
 		if !generatorFlags.DisableForm {
-			storeCredentials := forms.AskCredentials(pbCredentials)
-
-			if storeCredentials {
-				forms.AskStoreCredentials(pbCredentials)
-			}
+			selectedCollections = forms.AskCollectionSelection(collections.Items)
+			generatorFlags.CollectionsRelated = forms.AskCollectionsRelated(generatorFlags.CollectionsRelated)
+			generatorFlags.Output = forms.AskOutputTarget(generatorFlags.Output)
 		} else {
-			credentialExist, isEncrypted, err := credentials.CheckExistingCredentials()
-			if err != nil {
-				log.Fatal().Err(err).Msg("Could not check for credentials")
-			}
-
-			if credentialExist {
-				if isEncrypted {
-					err = pbCredentials.Decrypt(generatorFlags.EncryptionPassword)
-					if err != nil {
-						log.Fatal().Err(err).Msg("Could not decrypt stored credentials")
-					}
-				} else {
-					err = pbCredentials.Load()
-					if err != nil {
-						log.Fatal().Err(err).Msg("Could not load stored credentials")
-					}
-				}
-			}
+			selectedCollections = forms.GetSelectedCollections(generatorFlags, collections.Items)
 		}
 
-		pocketBase := pocketbase_api.New(pbCredentials)
-
-		err := pocketBase.Authenticate()
-		if err != nil {
-			log.Fatal().Err(err).Msg("Authentication error")
-		}
+		core.ProcessCollections(selectedCollections, collections.Items, generatorFlags)
+	})
 
-		collections, err := pocketBase.GetCollections()
-		if err != nil {
-			log.Fatal().Err(err).Msg("Could not retrieve collections")
-		}
+	rootCmd.AddCommand(cmd.GetGenerateMigrationCommand(false, func(cmd *cobra.Command, args []string, generatorFlags *cmd.GeneratorFlags) {
+		collections := getCollections(generatorFlags)
 
 		var selectedCollections []*pocketbase_api.Collection
 
 		if !generatorFlags.DisableForm {
 			selectedCollections = forms.AskCollectionSelection(collections.Items)
-			generatorFlags.Output = forms.AskOutputTarget(generatorFlags.Output)
+			generatorFlags.CollectionsRelated = forms.AskCollectionsRelated(generatorFlags.CollectionsRelated)
 		} else {
 			selectedCollections = forms.GetSelectedCollections(generatorFlags, collections.Items)
 		}
 
-		core.ProcessCollections(selectedCollections, collections.Items, generatorFlags)
-	})
+		core.ProcessMigration(selectedCollections, collections.Items, generatorFlags)
+	}))
 
 	err := rootCmd.Execute()
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed processing command")
 	}
 }
+
+// getCollections asks for or loads the credentials and fetches all collections
+func getCollections(generatorFlags *cmd.GeneratorFlags) *pocketbase_api.CollectionsResponse {
+	if generatorFlags.DisableLogs {
+		zerolog.SetGlobalLevel(4)
+	} else {
+		zerolog.SetGlobalLevel(1)
+	}
+
+	pbCredentials := &credentials.Credentials{
+		Host:     generatorFlags.Host,
+		Email:    generatorFlags.Email,
+		Password: generatorFlags.Password,
+	}
+
+	if !generatorFlags.DisableForm {
+		storeCredentials := forms.AskCredentials(pbCredentials)
+
+		if storeCredentials {
+			forms.AskStoreCredentials(pbCredentials)
+		}
+	} else {
+		credentialExist, isEncrypted, err := credentials.CheckExistingCredentials()
+		if err != nil {
+			log.Fatal().Err(err).Msg("Could not check for credentials")
+		}
+
+		if credentialExist {
+			if isEncrypted {
+				err = pbCredentials.Decrypt(generatorFlags.EncryptionPassword)
+				if err != nil {
+					log.Fatal().Err(err).Msg("Could not decrypt stored credentials")
+				}
+			} else {
+				err = pbCredentials.Load()
+				if err != nil {
+					log.Fatal().Err(err).Msg("Could not load stored credentials")
+				}
+			}
+		}
+	}
+
+	pocketBase := pocketbase_api.New(pbCredentials)
+
+	err := pocketBase.Authenticate()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Authentication error")
+	}
+
+	collections, err := pocketBase.GetCollections()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Could not retrieve collections")
+	}
+
+	return collections
+}
@@ -0,0 +1,3658 @@
+package collections
+
+import (
+	"cmp"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"mime"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+type UsersStruct struct {
+    Id string `json:"id"`;
+    Email string `json:"email"`;
+    EmailVisibility *bool `json:"emailVisibility"`;
+    Verified *bool `json:"verified"`;
+    Name *string `json:"name"`;
+    Avatar *string `json:"avatar"`;
+    Created *string `json:"created"`;
+    Updated *string `json:"updated"`;
+}
+
+var UsersFields = struct {
+    Id, Email, EmailVisibility, Verified, Name, Avatar, Created, Updated string
+}{
+Id: "id",
+Email: "email",
+EmailVisibility: "emailVisibility",
+Verified: "verified",
+Name: "name",
+Avatar: "avatar",
+Created: "created",
+Updated: "updated",
+}
+
+// Validate checks the field options of the users collection without a database, errors are returned as
+// *ValidationError. Relations are not checked for existence and files are only counted, use the
+// Users_ValidateXxxUpload functions for uploads.
+func (s UsersStruct) Validate() error {
+	errs := validation.Errors{}
+	if err := validateEmail(s.Email, true, []string(nil), []string(nil)); err != nil { errs["email"] = err }
+	if err := validateRequired(false, !deref(s.EmailVisibility)); err != nil { errs["emailVisibility"] = err }
+	if err := validateRequired(false, !deref(s.Verified)); err != nil { errs["verified"] = err }
+	if err := validateText(deref(s.Name), false, 0, 255, ""); err != nil { errs["name"] = err }
+	if err := validateValues(nonEmpty(deref(s.Avatar)), false, 0, 1, []string(nil)); err != nil { errs["avatar"] = err }
+	if len(errs) == 0 { return nil }
+	return newRecordError(CollectionUsers, errs)
+}
+
+// Users_ValidateAvatarUpload checks the size and mime type of a file before it is uploaded to avatar
+func Users_ValidateAvatarUpload(name string, size int64) error {
+	return validateUpload(name, size, 0, []string{"image/jpeg", "image/png"})
+}
+
+// UsersCreate holds the writable fields of a new users record, optional fields are only set if they are not nil
+type UsersCreate struct {
+    Email string `json:"email"`
+    EmailVisibility *bool `json:"emailVisibility,omitempty"`
+    Verified *bool `json:"verified,omitempty"`
+    Name *string `json:"name,omitempty"`
+    Avatar *filesystem.File `json:"-"`
+    Password string `json:"password"`
+}
+
+// ApplyTo sets the fields on record, use it with the record returned by Users_New
+func (d UsersCreate) ApplyTo(record *UsersRecord) {
+	record.Set("email", d.Email)
+	if d.EmailVisibility != nil { record.Set("emailVisibility", *d.EmailVisibility) }
+	if d.Verified != nil { record.Set("verified", *d.Verified) }
+	if d.Name != nil { record.Set("name", *d.Name) }
+	if d.Avatar != nil { record.Set("avatar", d.Avatar) }
+	if d.Password != "" { record.SetPassword(d.Password) }
+}
+
+// UsersUpdate holds the writable fields of a users record for patching, only fields which are not nil are changed
+type UsersUpdate struct {
+    Email *string `json:"email,omitempty"`
+    EmailVisibility *bool `json:"emailVisibility,omitempty"`
+    Verified *bool `json:"verified,omitempty"`
+    Name *string `json:"name,omitempty"`
+    Avatar *filesystem.File `json:"-"`
+    Password *string `json:"password,omitempty"`
+}
+
+// ApplyTo sets the fields which are not nil on record
+func (d UsersUpdate) ApplyTo(record *UsersRecord) {
+	if d.Email != nil { record.Set("email", *d.Email) }
+	if d.EmailVisibility != nil { record.Set("emailVisibility", *d.EmailVisibility) }
+	if d.Verified != nil { record.Set("verified", *d.Verified) }
+	if d.Name != nil { record.Set("name", *d.Name) }
+	if d.Avatar != nil { record.Set("avatar", d.Avatar) }
+	if d.Password != nil { record.SetPassword(*d.Password) }
+}
+
+var UsersFilter = struct {
+    Id StringFilterField
+    Email StringFilterField
+    EmailVisibility BoolFilterField
+    Verified BoolFilterField
+    Name StringFilterField
+    Avatar StringFilterField
+    Created DateFilterField
+    Updated DateFilterField
+}{
+    Id: StringFilterField{name: "id"},
+    Email: StringFilterField{name: "email"},
+    EmailVisibility: BoolFilterField{name: "emailVisibility"},
+    Verified: BoolFilterField{name: "verified"},
+    Name: StringFilterField{name: "name"},
+    Avatar: StringFilterField{name: "avatar"},
+    Created: DateFilterField{name: "created"},
+    Updated: DateFilterField{name: "updated"},
+}
+
+func Users_FindRecordsWhere(app core.App, filter Filter, sort string, limit int, offset int) ([]*UsersRecord, error) {
+	expr, params := filter.Build()
+	return Users_FindRecordsByFilter(app, expr, sort, limit, offset, params)
+}
+
+var UsersSort = struct {
+    Id SortField
+    Email SortField
+    EmailVisibility SortField
+    Verified SortField
+    Name SortField
+    Avatar SortField
+    Created SortField
+    Updated SortField
+}{
+    Id: SortField{name: "id"},
+    Email: SortField{name: "email"},
+    EmailVisibility: SortField{name: "emailVisibility"},
+    Verified: SortField{name: "verified"},
+    Name: SortField{name: "name"},
+    Avatar: SortField{name: "avatar"},
+    Created: SortField{name: "created"},
+    Updated: SortField{name: "updated"},
+}
+
+func Users_List(app core.App, opts ListOptions) (ListResult[*UsersRecord], error) {
+	page, perPage := opts.pagination()
+	expr, params := opts.Filter.Build()
+	total, err := countRecordsByFilter(app, CollectionUsers, expr, params)
+	if err != nil { return ListResult[*UsersRecord]{}, err }
+	records, err := Users_FindRecordsByFilter(app, expr, opts.sort(), perPage, (page-1)*perPage, params)
+	if err != nil { return ListResult[*UsersRecord]{}, err }
+	return newListResult(records, page, perPage, total), nil
+}
+
+// Users_All iterates over all records matching filter without loading them at once, see AllBatchSize
+func Users_All(app core.App, filter string, params ...dbx.Params) iter.Seq2[*UsersRecord, error] {
+	return func(yield func(*UsersRecord, error) bool) {
+		for _record, err := range allRecords(app, CollectionUsers, filter, params...) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(Users_Wrap(_record), nil) { return }
+		}
+	}
+}
+
+const (
+    UsersExpandPostsViaAuthor = "posts_via_author"
+    UsersExpandPostsViaReviewers = "posts_via_reviewers"
+)
+
+
+var _ core.RecordProxy = (*UsersRecord)(nil)
+
+type UsersRecord struct {
+    core.BaseRecordProxy
+}
+
+func (a *UsersRecord) Id() string {
+    return a.GetString("id")
+}
+
+
+
+// OriginalId returns id as it was loaded from the database, the zero value for new records
+func (a *UsersRecord) OriginalId() string {
+	return a.Original().GetString("id")
+}
+
+// IdChanged reports whether id differs from the value loaded from the database
+func (a *UsersRecord) IdChanged() bool {
+	return a.OriginalId() != a.Id()
+}
+
+
+
+
+// OriginalEmail returns email as it was loaded from the database, the zero value for new records
+func (a *UsersRecord) OriginalEmail() string {
+	return a.Original().GetString("email")
+}
+
+// EmailChanged reports whether email differs from the value loaded from the database
+func (a *UsersRecord) EmailChanged() bool {
+	return a.OriginalEmail() != a.Email()
+}
+
+
+
+
+// OriginalEmailVisibility returns emailVisibility as it was loaded from the database, the zero value for new records
+func (a *UsersRecord) OriginalEmailVisibility() bool {
+	return a.Original().GetBool("emailVisibility")
+}
+
+// EmailVisibilityChanged reports whether emailVisibility differs from the value loaded from the database
+func (a *UsersRecord) EmailVisibilityChanged() bool {
+	return a.OriginalEmailVisibility() != a.EmailVisibility()
+}
+
+
+
+
+// OriginalVerified returns verified as it was loaded from the database, the zero value for new records
+func (a *UsersRecord) OriginalVerified() bool {
+	return a.Original().GetBool("verified")
+}
+
+// VerifiedChanged reports whether verified differs from the value loaded from the database
+func (a *UsersRecord) VerifiedChanged() bool {
+	return a.OriginalVerified() != a.Verified()
+}
+
+func (a *UsersRecord) Name() string {
+    return a.GetString("name")
+}
+
+
+func (a *UsersRecord) SetName(name string) {
+    a.Set("name", name)
+}
+
+// OriginalName returns name as it was loaded from the database, the zero value for new records
+func (a *UsersRecord) OriginalName() string {
+	return a.Original().GetString("name")
+}
+
+// NameChanged reports whether name differs from the value loaded from the database
+func (a *UsersRecord) NameChanged() bool {
+	return a.OriginalName() != a.Name()
+}
+
+func (a *UsersRecord) Avatar() string {
+    return a.GetString("avatar")
+}
+
+
+func (a *UsersRecord) SetAvatar(avatar string) {
+    a.Set("avatar", avatar)
+}
+
+// OriginalAvatar returns avatar as it was loaded from the database, the zero value for new records
+func (a *UsersRecord) OriginalAvatar() string {
+	return a.Original().GetString("avatar")
+}
+
+// AvatarChanged reports whether avatar differs from the value loaded from the database
+func (a *UsersRecord) AvatarChanged() bool {
+	return a.OriginalAvatar() != a.Avatar()
+}
+
+// thumb sizes of users.avatar for AvatarURL
+const (
+    UsersAvatarThumb100x100 = "100x100"
+)
+
+// AvatarURL returns the url of the avatar file, thumb is empty or one of the thumb sizes of the field. The url is
+// empty if no file is set.
+func (a *UsersRecord) AvatarURL(baseURL string, thumb string) string {
+	name := a.GetString("avatar")
+	if name == "" { return "" }
+	return fileURL(baseURL, CollectionUsers, a.ProxyRecord().Id, name, thumb)
+}
+
+// SetAvatarFromPath replaces avatar with the file at path, it is uploaded and the previous file is deleted from the
+// storage on save (accepted mime types: image/jpeg, image/png)
+func (a *UsersRecord) SetAvatarFromPath(path string) error {
+	file, err := filesystem.NewFileFromPath(path)
+	if err != nil { return err }
+	a.Set("avatar", file)
+	return nil
+}
+
+// SetAvatarFromBytes replaces avatar with a file named name containing data, it is uploaded and the previous file is
+// deleted from the storage on save (accepted mime types: image/jpeg, image/png)
+func (a *UsersRecord) SetAvatarFromBytes(name string, data []byte) error {
+	file, err := filesystem.NewFileFromBytes(data, name)
+	if err != nil { return err }
+	a.Set("avatar", file)
+	return nil
+}
+
+// DeleteAvatar removes the files named names from avatar, they are deleted from the storage on save
+func (a *UsersRecord) DeleteAvatar(names ...string) {
+	a.Set("avatar-", names)
+}
+
+func (a *UsersRecord) Created() types.DateTime {
+    return a.GetDateTime("created")
+}
+
+
+
+// OriginalCreated returns created as it was loaded from the database, the zero value for new records
+func (a *UsersRecord) OriginalCreated() types.DateTime {
+	return a.Original().GetDateTime("created")
+}
+
+// CreatedChanged reports whether created differs from the value loaded from the database
+func (a *UsersRecord) CreatedChanged() bool {
+	return !a.OriginalCreated().Equal(a.Created())
+}
+
+func (a *UsersRecord) Updated() types.DateTime {
+    return a.GetDateTime("updated")
+}
+
+
+
+// OriginalUpdated returns updated as it was loaded from the database, the zero value for new records
+func (a *UsersRecord) OriginalUpdated() types.DateTime {
+	return a.Original().GetDateTime("updated")
+}
+
+// UpdatedChanged reports whether updated differs from the value loaded from the database
+func (a *UsersRecord) UpdatedChanged() bool {
+	return !a.OriginalUpdated().Equal(a.Updated())
+}
+
+
+// PostsViaAuthor returns all posts records referencing this record in their author field, filter is optional
+func (a *UsersRecord) PostsViaAuthor(app core.App, filter string, params ...dbx.Params) ([]*PostsRecord, error) {
+	_filter := "author = {:viaRecordId}"
+	if filter != "" { _filter += " && (" + filter + ")" }
+	_params := dbx.Params{"viaRecordId": a.Record.Id}
+	for _, p := range params {
+		for k, v := range p { _params[k] = v }
+	}
+	return Posts_FindRecordsByFilter(app, _filter, "", 0, 0, _params)
+}
+
+
+// PostsViaReviewers returns all posts records referencing this record in their reviewers field, filter is optional
+func (a *UsersRecord) PostsViaReviewers(app core.App, filter string, params ...dbx.Params) ([]*PostsRecord, error) {
+	_filter := "reviewers:each ?= {:viaRecordId}"
+	if filter != "" { _filter += " && (" + filter + ")" }
+	_params := dbx.Params{"viaRecordId": a.Record.Id}
+	for _, p := range params {
+		for k, v := range p { _params[k] = v }
+	}
+	return Posts_FindRecordsByFilter(app, _filter, "", 0, 0, _params)
+}
+
+
+
+// PublicExportStruct converts the record with a json round trip of PublicExport, conversion errors result in
+// zero fields. Prefer ToStruct.
+func (a *UsersRecord) PublicExportStruct() UsersStruct {
+	bytes, _ := json.Marshal(a.PublicExport())
+	var record = UsersStruct{}
+	_ = json.Unmarshal(bytes, &record)
+	return record
+}
+
+// ChangedFields returns the names of the fields which differ from the values loaded from the database, all fields
+// with a value are changed for new records
+func (a *UsersRecord) ChangedFields() []string {
+	var fields []string
+	if a.IdChanged() { fields = append(fields, UsersFields.Id) }
+	if a.EmailChanged() { fields = append(fields, UsersFields.Email) }
+	if a.EmailVisibilityChanged() { fields = append(fields, UsersFields.EmailVisibility) }
+	if a.VerifiedChanged() { fields = append(fields, UsersFields.Verified) }
+	if a.NameChanged() { fields = append(fields, UsersFields.Name) }
+	if a.AvatarChanged() { fields = append(fields, UsersFields.Avatar) }
+	if a.CreatedChanged() { fields = append(fields, UsersFields.Created) }
+	if a.UpdatedChanged() { fields = append(fields, UsersFields.Updated) }
+	return fields
+}
+
+// ToStruct converts the record and its expanded relations field by field, it is a faster replacement of
+// PublicExportStruct which also reports json fields which can not be converted.
+func (a *UsersRecord) ToStruct() (UsersStruct, error) {
+	s := UsersStruct{
+		Id: a.Id(),
+		EmailVisibility: ptr(a.EmailVisibility()),
+		Verified: ptr(a.Verified()),
+		Name: ptr(a.Name()),
+		Avatar: ptr(a.Avatar()),
+		Created: ptr(a.Created().String()),
+		Updated: ptr(a.Updated().String()),
+	}
+
+	if a.EmailVisibility() { s.Email = a.Email() }
+
+	return s, nil
+}
+
+// Users_FromStruct creates an unsaved record with the fields of s, s.Expand is ignored
+func Users_FromStruct(app core.App, s UsersStruct) (*UsersRecord, error) {
+	c, err := app.FindCollectionByNameOrId(CollectionUsers)
+	if err != nil { return nil, err }
+
+	record := core.NewRecord(c)
+	record.Set("id", s.Id)
+	record.Set("email", s.Email)
+	record.Set("emailVisibility", deref(s.EmailVisibility))
+	record.Set("verified", deref(s.Verified))
+	record.Set("name", deref(s.Name))
+	record.Set("avatar", deref(s.Avatar))
+	if value, err := types.ParseDateTime(deref(s.Created)); err == nil { record.SetRaw("created", value) }
+	if value, err := types.ParseDateTime(deref(s.Updated)); err == nil { record.SetRaw("updated", value) }
+
+	return Users_Wrap(record), nil
+}
+
+// Save validates and saves the record, validation errors are returned as *ValidationError
+func (a *UsersRecord) Save(app core.App) error {
+	return newRecordError(CollectionUsers, app.Save(a))
+}
+
+// Delete deletes the record together with the records referencing it with cascade delete. It fails if the record is
+// still referenced by a required relation without cascade delete, validation errors of hooks are returned as
+// *ValidationError.
+func (a *UsersRecord) Delete(app core.App) error {
+	return newRecordError(CollectionUsers, app.Delete(a))
+}
+
+// Validate validates the record without saving it, validation errors are returned as *ValidationError
+func (a *UsersRecord) Validate(app core.App) error {
+	return newRecordError(CollectionUsers, app.Validate(a))
+}
+
+// Users_RunInTransaction runs fn in a database transaction which is rolled back if fn returns an error. Use tx
+// instead of app for all generated helpers called in fn.
+func Users_RunInTransaction(app core.App, fn func(tx core.App) error) error {
+	return app.RunInTransaction(fn)
+}
+
+type PostsStatusOptions string
+const (
+    PostsStatusOptions_Draft PostsStatusOptions = "draft"
+    PostsStatusOptions_Published PostsStatusOptions = "published"
+    PostsStatusOptions_Archived PostsStatusOptions = "archived"
+)
+
+type PostsTagsOptions string
+const (
+    PostsTagsOptions_Go PostsTagsOptions = "go"
+    PostsTagsOptions_Web PostsTagsOptions = "web"
+    PostsTagsOptions_Db PostsTagsOptions = "db"
+)
+
+type PostsExpanded struct {
+    Author UsersStruct `json:"author"`;
+    Reviewers *[]UsersStruct `json:"reviewers"`;
+    Category *CategoriesStruct `json:"category"`;
+}
+
+type PostsStruct struct {
+    Expand PostsExpanded `json:"expand"`
+    Id string `json:"id"`;
+    Title string `json:"title"`;
+    Slug string `json:"slug"`;
+    Body *string `json:"body"`;
+    Views *float32 `json:"views"`;
+    Rating float32 `json:"rating"`;
+    Published *bool `json:"published"`;
+    Status PostsStatusOptions `json:"status"`;
+    Tags *[]PostsTagsOptions `json:"tags"`;
+    Author string `json:"author"`;
+    Reviewers *[]string `json:"reviewers"`;
+    Images *[]string `json:"images"`;
+    Meta *map[string]interface{} `json:"meta"`;
+    Website *string `json:"website"`;
+    Contact *string `json:"contact"`;
+    PublishedAt *string `json:"published_at"`;
+    Due *string `json:"due"`;
+    Category string `json:"category"`;
+    Created *string `json:"created"`;
+    Updated *string `json:"updated"`;
+}
+
+var PostsFields = struct {
+    Id, Title, Slug, Body, Views, Rating, Published, Status, Tags, Author, Reviewers, Images, Meta, Website, Contact, PublishedAt, Due, Category, Created, Updated string
+}{
+Id: "id",
+Title: "title",
+Slug: "slug",
+Body: "body",
+Views: "views",
+Rating: "rating",
+Published: "published",
+Status: "status",
+Tags: "tags",
+Author: "author",
+Reviewers: "reviewers",
+Images: "images",
+Meta: "meta",
+Website: "website",
+Contact: "contact",
+PublishedAt: "published_at",
+Due: "due",
+Category: "category",
+Created: "created",
+Updated: "updated",
+}
+
+// Validate checks the field options of the posts collection without a database, errors are returned as
+// *ValidationError. Relations are not checked for existence and files are only counted, use the
+// Posts_ValidateXxxUpload functions for uploads.
+func (s PostsStruct) Validate() error {
+	errs := validation.Errors{}
+	if err := validateText(s.Title, true, 3, 100, ""); err != nil { errs["title"] = err }
+	if err := validateText(s.Slug, true, 0, 0, "^[a-z0-9-]+$"); err != nil { errs["slug"] = err }
+	if err := validateEditor(deref(s.Body), false, 0); err != nil { errs["body"] = err }
+	if err := validateNumber(float64(deref(s.Views)), false, limit(0), nil, true); err != nil { errs["views"] = err }
+	if err := validateNumber(float64(s.Rating), true, limit(1), limit(5), false); err != nil { errs["rating"] = err }
+	if err := validateRequired(false, !deref(s.Published)); err != nil { errs["published"] = err }
+	if err := validateValues(nonEmpty(s.Status), true, 0, 1, []string{"draft", "published", "archived"}); err != nil { errs["status"] = err }
+	if err := validateValues(nonEmpty(deref(s.Tags)...), false, 0, 3, []string{"go", "web", "db"}); err != nil { errs["tags"] = err }
+	if err := validateValues(nonEmpty(s.Author), true, 0, 1, []string(nil)); err != nil { errs["author"] = err }
+	if err := validateValues(nonEmpty(deref(s.Reviewers)...), false, 0, 5, []string(nil)); err != nil { errs["reviewers"] = err }
+	if err := validateValues(nonEmpty(deref(s.Images)...), false, 0, 5, []string(nil)); err != nil { errs["images"] = err }
+	if err := validateJSON(deref(s.Meta), false, len(deref(s.Meta)) == 0, 0); err != nil { errs["meta"] = err }
+	if err := validateURL(deref(s.Website), false, []string{"example.com"}, []string(nil)); err != nil { errs["website"] = err }
+	if err := validateEmail(deref(s.Contact), false, []string(nil), []string{"spam.com"}); err != nil { errs["contact"] = err }
+	if err := validateDate(deref(s.Due), false); err != nil { errs["due"] = err }
+	if err := validateValues(nonEmpty(s.Category), false, 0, 1, []string(nil)); err != nil { errs["category"] = err }
+	if len(errs) == 0 { return nil }
+	return newRecordError(CollectionPosts, errs)
+}
+
+// Posts_ValidateImagesUpload checks the size and mime type of a file before it is uploaded to images
+func Posts_ValidateImagesUpload(name string, size int64) error {
+	return validateUpload(name, size, 5242880, []string{"image/png"})
+}
+
+// PostsCreate holds the writable fields of a new posts record, optional fields are only set if they are not nil
+type PostsCreate struct {
+    Title string `json:"title"`
+    Slug string `json:"slug"`
+    Body *string `json:"body,omitempty"`
+    Views *float64 `json:"views,omitempty"`
+    Rating float64 `json:"rating"`
+    Published *bool `json:"published,omitempty"`
+    Status PostsStatusOptions `json:"status"`
+    Tags []PostsTagsOptions `json:"tags,omitempty"`
+    Author string `json:"author"`
+    Reviewers []string `json:"reviewers,omitempty"`
+    Images []*filesystem.File `json:"-"`
+    Meta any `json:"meta,omitempty"`
+    Website *string `json:"website,omitempty"`
+    Contact *string `json:"contact,omitempty"`
+    Due *types.DateTime `json:"due,omitempty"`
+    Category *string `json:"category,omitempty"`
+}
+
+// ApplyTo sets the fields on record, use it with the record returned by Posts_New
+func (d PostsCreate) ApplyTo(record *PostsRecord) {
+	record.Set("title", d.Title)
+	record.Set("slug", d.Slug)
+	if d.Body != nil { record.Set("body", *d.Body) }
+	if d.Views != nil { record.Set("views", *d.Views) }
+	record.Set("rating", d.Rating)
+	if d.Published != nil { record.Set("published", *d.Published) }
+	record.Set("status", string(d.Status))
+	if d.Tags != nil { record.Set("tags", nonEmpty(d.Tags...)) }
+	record.Set("author", d.Author)
+	if d.Reviewers != nil { record.Set("reviewers", d.Reviewers) }
+	if d.Images != nil { record.Set("images", d.Images) }
+	if d.Meta != nil { record.Set("meta", d.Meta) }
+	if d.Website != nil { record.Set("website", *d.Website) }
+	if d.Contact != nil { record.Set("contact", *d.Contact) }
+	if d.Due != nil { record.Set("due", *d.Due) }
+	if d.Category != nil { record.Set("category", *d.Category) }
+}
+
+// PostsUpdate holds the writable fields of a posts record for patching, only fields which are not nil are changed
+type PostsUpdate struct {
+    Title *string `json:"title,omitempty"`
+    Slug *string `json:"slug,omitempty"`
+    Body *string `json:"body,omitempty"`
+    Views *float64 `json:"views,omitempty"`
+    Rating *float64 `json:"rating,omitempty"`
+    Published *bool `json:"published,omitempty"`
+    Status *PostsStatusOptions `json:"status,omitempty"`
+    Tags []PostsTagsOptions `json:"tags,omitempty"`
+    Author *string `json:"author,omitempty"`
+    Reviewers []string `json:"reviewers,omitempty"`
+    Images []*filesystem.File `json:"-"`
+    Meta any `json:"meta,omitempty"`
+    Website *string `json:"website,omitempty"`
+    Contact *string `json:"contact,omitempty"`
+    Due *types.DateTime `json:"due,omitempty"`
+    Category *string `json:"category,omitempty"`
+}
+
+// ApplyTo sets the fields which are not nil on record
+func (d PostsUpdate) ApplyTo(record *PostsRecord) {
+	if d.Title != nil { record.Set("title", *d.Title) }
+	if d.Slug != nil { record.Set("slug", *d.Slug) }
+	if d.Body != nil { record.Set("body", *d.Body) }
+	if d.Views != nil { record.Set("views", *d.Views) }
+	if d.Rating != nil { record.Set("rating", *d.Rating) }
+	if d.Published != nil { record.Set("published", *d.Published) }
+	if d.Status != nil { record.Set("status", string(*d.Status)) }
+	if d.Tags != nil { record.Set("tags", nonEmpty(d.Tags...)) }
+	if d.Author != nil { record.Set("author", *d.Author) }
+	if d.Reviewers != nil { record.Set("reviewers", d.Reviewers) }
+	if d.Images != nil { record.Set("images", d.Images) }
+	if d.Meta != nil { record.Set("meta", d.Meta) }
+	if d.Website != nil { record.Set("website", *d.Website) }
+	if d.Contact != nil { record.Set("contact", *d.Contact) }
+	if d.Due != nil { record.Set("due", *d.Due) }
+	if d.Category != nil { record.Set("category", *d.Category) }
+}
+
+var PostsFilter = struct {
+    Id StringFilterField
+    Title StringFilterField
+    Slug StringFilterField
+    Body StringFilterField
+    Views NumberFilterField
+    Rating NumberFilterField
+    Published BoolFilterField
+    Status EnumFilterField[PostsStatusOptions]
+    Tags MultiEnumFilterField[PostsTagsOptions]
+    Author RelationFilterField
+    Reviewers MultiValueFilterField
+    Images MultiValueFilterField
+    Website StringFilterField
+    Contact StringFilterField
+    PublishedAt DateFilterField
+    Due DateFilterField
+    Category RelationFilterField
+    Created DateFilterField
+    Updated DateFilterField
+}{
+    Id: StringFilterField{name: "id"},
+    Title: StringFilterField{name: "title"},
+    Slug: StringFilterField{name: "slug"},
+    Body: StringFilterField{name: "body"},
+    Views: NumberFilterField{name: "views"},
+    Rating: NumberFilterField{name: "rating"},
+    Published: BoolFilterField{name: "published"},
+    Status: EnumFilterField[PostsStatusOptions]{name: "status"},
+    Tags: MultiEnumFilterField[PostsTagsOptions]{name: "tags"},
+    Author: RelationFilterField{name: "author"},
+    Reviewers: MultiValueFilterField{name: "reviewers"},
+    Images: MultiValueFilterField{name: "images"},
+    Website: StringFilterField{name: "website"},
+    Contact: StringFilterField{name: "contact"},
+    PublishedAt: DateFilterField{name: "published_at"},
+    Due: DateFilterField{name: "due"},
+    Category: RelationFilterField{name: "category"},
+    Created: DateFilterField{name: "created"},
+    Updated: DateFilterField{name: "updated"},
+}
+
+func Posts_FindRecordsWhere(app core.App, filter Filter, sort string, limit int, offset int) ([]*PostsRecord, error) {
+	expr, params := filter.Build()
+	return Posts_FindRecordsByFilter(app, expr, sort, limit, offset, params)
+}
+
+var PostsSort = struct {
+    Id SortField
+    Title SortField
+    Slug SortField
+    Body SortField
+    Views SortField
+    Rating SortField
+    Published SortField
+    Status SortField
+    Author SortField
+    Website SortField
+    Contact SortField
+    PublishedAt SortField
+    Due SortField
+    Category SortField
+    Created SortField
+    Updated SortField
+}{
+    Id: SortField{name: "id"},
+    Title: SortField{name: "title"},
+    Slug: SortField{name: "slug"},
+    Body: SortField{name: "body"},
+    Views: SortField{name: "views"},
+    Rating: SortField{name: "rating"},
+    Published: SortField{name: "published"},
+    Status: SortField{name: "status"},
+    Author: SortField{name: "author"},
+    Website: SortField{name: "website"},
+    Contact: SortField{name: "contact"},
+    PublishedAt: SortField{name: "published_at"},
+    Due: SortField{name: "due"},
+    Category: SortField{name: "category"},
+    Created: SortField{name: "created"},
+    Updated: SortField{name: "updated"},
+}
+
+func Posts_List(app core.App, opts ListOptions) (ListResult[*PostsRecord], error) {
+	page, perPage := opts.pagination()
+	expr, params := opts.Filter.Build()
+	total, err := countRecordsByFilter(app, CollectionPosts, expr, params)
+	if err != nil { return ListResult[*PostsRecord]{}, err }
+	records, err := Posts_FindRecordsByFilter(app, expr, opts.sort(), perPage, (page-1)*perPage, params)
+	if err != nil { return ListResult[*PostsRecord]{}, err }
+	return newListResult(records, page, perPage, total), nil
+}
+
+// Posts_All iterates over all records matching filter without loading them at once, see AllBatchSize
+func Posts_All(app core.App, filter string, params ...dbx.Params) iter.Seq2[*PostsRecord, error] {
+	return func(yield func(*PostsRecord, error) bool) {
+		for _record, err := range allRecords(app, CollectionPosts, filter, params...) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(Posts_Wrap(_record), nil) { return }
+		}
+	}
+}
+
+const (
+    PostsExpandCategoriesViaFeatured = "categories_via_featured"
+)
+
+
+var _ core.RecordProxy = (*PostsRecord)(nil)
+
+type PostsRecord struct {
+    core.BaseRecordProxy
+}
+
+func (a *PostsRecord) Id() string {
+    return a.GetString("id")
+}
+
+
+
+// OriginalId returns id as it was loaded from the database, the zero value for new records
+func (a *PostsRecord) OriginalId() string {
+	return a.Original().GetString("id")
+}
+
+// IdChanged reports whether id differs from the value loaded from the database
+func (a *PostsRecord) IdChanged() bool {
+	return a.OriginalId() != a.Id()
+}
+
+func (a *PostsRecord) Title() string {
+    return a.GetString("title")
+}
+
+
+func (a *PostsRecord) SetTitle(title string) {
+    a.Set("title", title)
+}
+
+// OriginalTitle returns title as it was loaded from the database, the zero value for new records
+func (a *PostsRecord) OriginalTitle() string {
+	return a.Original().GetString("title")
+}
+
+// TitleChanged reports whether title differs from the value loaded from the database
+func (a *PostsRecord) TitleChanged() bool {
+	return a.OriginalTitle() != a.Title()
+}
+
+func (a *PostsRecord) Slug() string {
+    return a.GetString("slug")
+}
+
+
+func (a *PostsRecord) SetSlug(slug string) {
+    a.Set("slug", slug)
+}
+
+// OriginalSlug returns slug as it was loaded from the database, the zero value for new records
+func (a *PostsRecord) OriginalSlug() string {
+	return a.Original().GetString("slug")
+}
+
+// SlugChanged reports whether slug differs from the value loaded from the database
+func (a *PostsRecord) SlugChanged() bool {
+	return a.OriginalSlug() != a.Slug()
+}
+
+func (a *PostsRecord) Body() string {
+    return a.GetString("body")
+}
+
+
+func (a *PostsRecord) SetBody(body string) {
+    a.Set("body", body)
+}
+
+// OriginalBody returns body as it was loaded from the database, the zero value for new records
+func (a *PostsRecord) OriginalBody() string {
+	return a.Original().GetString("body")
+}
+
+// BodyChanged reports whether body differs from the value loaded from the database
+func (a *PostsRecord) BodyChanged() bool {
+	return a.OriginalBody() != a.Body()
+}
+
+func (a *PostsRecord) ViewsInt() int {
+    return a.GetInt("views")
+}
+
+func (a *PostsRecord) Views() float64 {
+    return a.GetFloat("views")
+}
+
+
+func (a *PostsRecord) SetViews(views float64) {
+    a.Set("views", views)
+}
+
+// IncrementViews adds n to views, use a negative n to decrement
+func (a *PostsRecord) IncrementViews(n float64) {
+	a.Set("views+", n)
+}
+
+// OriginalViews returns views as it was loaded from the database, the zero value for new records
+func (a *PostsRecord) OriginalViews() float64 {
+	return a.Original().GetFloat("views")
+}
+
+// ViewsChanged reports whether views differs from the value loaded from the database
+func (a *PostsRecord) ViewsChanged() bool {
+	return a.OriginalViews() != a.Views()
+}
+
+func (a *PostsRecord) RatingInt() int {
+    return a.GetInt("rating")
+}
+
+func (a *PostsRecord) Rating() float64 {
+    return a.GetFloat("rating")
+}
+
+
+func (a *PostsRecord) SetRating(rating float64) {
+    a.Set("rating", rating)
+}
+
+// IncrementRating adds n to rating, use a negative n to decrement
+func (a *PostsRecord) IncrementRating(n float64) {
+	a.Set("rating+", n)
+}
+
+// OriginalRating returns rating as it was loaded from the database, the zero value for new records
+func (a *PostsRecord) OriginalRating() float64 {
+	return a.Original().GetFloat("rating")
+}
+
+// RatingChanged reports whether rating differs from the value loaded from the database
+func (a *PostsRecord) RatingChanged() bool {
+	return a.OriginalRating() != a.Rating()
+}
+
+func (a *PostsRecord) Published() bool {
+    return a.GetBool("published")
+}
+
+
+func (a *PostsRecord) SetPublished(published bool) {
+    a.Set("published", published)
+}
+
+// OriginalPublished returns published as it was loaded from the database, the zero value for new records
+func (a *PostsRecord) OriginalPublished() bool {
+	return a.Original().GetBool("published")
+}
+
+// PublishedChanged reports whether published differs from the value loaded from the database
+func (a *PostsRecord) PublishedChanged() bool {
+	return a.OriginalPublished() != a.Published()
+}
+
+func (a *PostsRecord) Status() string {
+    return a.GetString("status")
+}
+
+
+func (a *PostsRecord) SetStatus(status string) {
+    a.Set("status", status)
+}
+
+// OriginalStatus returns status as it was loaded from the database, the zero value for new records
+func (a *PostsRecord) OriginalStatus() string {
+	return a.Original().GetString("status")
+}
+
+// StatusChanged reports whether status differs from the value loaded from the database
+func (a *PostsRecord) StatusChanged() bool {
+	return a.OriginalStatus() != a.Status()
+}
+
+func (a *PostsRecord) Tags() []string {
+    return a.GetStringSlice("tags")
+}
+
+
+func (a *PostsRecord) SetTags(tags []string) {
+    a.Set("tags", tags)
+}
+
+// AddTags appends values to tags
+func (a *PostsRecord) AddTags(values ...string) {
+	a.Set("tags+", values)
+}
+
+// PrependTags inserts values at the start of tags
+func (a *PostsRecord) PrependTags(values ...string) {
+	a.Set("+tags", values)
+}
+
+// RemoveTags removes values from tags
+func (a *PostsRecord) RemoveTags(values ...string) {
+	a.Set("tags-", values)
+}
+
+// OriginalTags returns tags as it was loaded from the database, the zero value for new records
+func (a *PostsRecord) OriginalTags() []string {
+	return a.Original().GetStringSlice("tags")
+}
+
+// TagsChanged reports whether tags differs from the value loaded from the database
+func (a *PostsRecord) TagsChanged() bool {
+	return !slices.Equal(a.OriginalTags(), a.Tags())
+}
+
+func (a *PostsRecord) Author() string {
+    return a.GetString("author")
+}
+
+
+func (a *PostsRecord) SetAuthor(author string) {
+    a.Set("author", author)
+}
+
+// OriginalAuthor returns author as it was loaded from the database, the zero value for new records
+func (a *PostsRecord) OriginalAuthor() string {
+	return a.Original().GetString("author")
+}
+
+// AuthorChanged reports whether author differs from the value loaded from the database
+func (a *PostsRecord) AuthorChanged() bool {
+	return a.OriginalAuthor() != a.Author()
+}
+
+func (a *PostsRecord) ExpandAuthor(app core.App) (*UsersRecord, error) {
+	if errs := app.ExpandRecord(a.Record, []string{"author"}, nil); len(errs) > 0 {
+		return nil, errs["author"]
+	}
+	return a.ExpandedAuthor(), nil
+}
+
+// ExpandedAuthor returns the already expanded author relation without querying the database
+func (a *PostsRecord) ExpandedAuthor() *UsersRecord {
+	record := a.ExpandedOne("author")
+	if record == nil { return nil }
+	return Users_Wrap(record)
+}
+
+func (a *PostsRecord) Reviewers() []string {
+    return a.GetStringSlice("reviewers")
+}
+
+
+func (a *PostsRecord) SetReviewers(reviewers []string) {
+    a.Set("reviewers", reviewers)
+}
+
+// AddReviewers appends ids to reviewers
+func (a *PostsRecord) AddReviewers(ids ...string) {
+	a.Set("reviewers+", ids)
+}
+
+// PrependReviewers inserts ids at the start of reviewers
+func (a *PostsRecord) PrependReviewers(ids ...string) {
+	a.Set("+reviewers", ids)
+}
+
+// RemoveReviewers removes ids from reviewers
+func (a *PostsRecord) RemoveReviewers(ids ...string) {
+	a.Set("reviewers-", ids)
+}
+
+// OriginalReviewers returns reviewers as it was loaded from the database, the zero value for new records
+func (a *PostsRecord) OriginalReviewers() []string {
+	return a.Original().GetStringSlice("reviewers")
+}
+
+// ReviewersChanged reports whether reviewers differs from the value loaded from the database
+func (a *PostsRecord) ReviewersChanged() bool {
+	return !slices.Equal(a.OriginalReviewers(), a.Reviewers())
+}
+
+func (a *PostsRecord) ExpandReviewers(app core.App) ([]*UsersRecord, error) {
+	if errs := app.ExpandRecord(a.Record, []string{"reviewers"}, nil); len(errs) > 0 {
+		return nil, errs["reviewers"]
+	}
+	return a.ExpandedReviewers(), nil
+}
+
+// ExpandedReviewers returns the already expanded reviewers relation without querying the database
+func (a *PostsRecord) ExpandedReviewers() []*UsersRecord {
+	_records := a.ExpandedAll("reviewers")
+	records := make([]*UsersRecord, len(_records))
+	for i, _record := range _records { records[i] = Users_Wrap(_record) }
+	return records
+}
+
+func (a *PostsRecord) Images() []string {
+    return a.GetStringSlice("images")
+}
+
+
+func (a *PostsRecord) SetImages(images []string) {
+    a.Set("images", images)
+}
+
+// OriginalImages returns images as it was loaded from the database, the zero value for new records
+func (a *PostsRecord) OriginalImages() []string {
+	return a.Original().GetStringSlice("images")
+}
+
+// ImagesChanged reports whether images differs from the value loaded from the database
+func (a *PostsRecord) ImagesChanged() bool {
+	return !slices.Equal(a.OriginalImages(), a.Images())
+}
+
+// thumb sizes of posts.images for ImagesURL
+const (
+    PostsImagesThumb0x100 = "0x100"
+    PostsImagesThumb50x50 = "50x50"
+)
+
+// ImagesURL returns the urls of all images files, thumb is empty or one of the thumb sizes of the field
+func (a *PostsRecord) ImagesURL(baseURL string, thumb string) []string {
+	names := a.GetStringSlice("images")
+	urls := make([]string, len(names))
+	for i, name := range names { urls[i] = fileURL(baseURL, CollectionPosts, a.ProxyRecord().Id, name, thumb) }
+	return urls
+}
+
+// SetImagesFromPath replaces all files of images with the files at paths, they are uploaded and the previous files are
+// deleted from the storage on save. Use AddImagesFromPath to keep the previous files (accepted mime types: image/png).
+func (a *PostsRecord) SetImagesFromPath(paths ...string) error {
+	files := make([]*filesystem.File, len(paths))
+	for i, path := range paths {
+		file, err := filesystem.NewFileFromPath(path)
+		if err != nil { return err }
+		files[i] = file
+	}
+	a.Set("images", files)
+	return nil
+}
+
+// SetImagesFromBytes replaces all files of images with a file named name containing data, it is uploaded and the
+// previous files are deleted from the storage on save. Use AddImagesFromBytes to keep the previous files (accepted mime types: image/png).
+func (a *PostsRecord) SetImagesFromBytes(name string, data []byte) error {
+	file, err := filesystem.NewFileFromBytes(data, name)
+	if err != nil { return err }
+	a.Set("images", file)
+	return nil
+}
+
+// AddImagesFromPath appends the files at paths to images, they are uploaded on save (accepted mime types: image/png)
+func (a *PostsRecord) AddImagesFromPath(paths ...string) error {
+	files := make([]*filesystem.File, len(paths))
+	for i, path := range paths {
+		file, err := filesystem.NewFileFromPath(path)
+		if err != nil { return err }
+		files[i] = file
+	}
+	a.Set("images+", files)
+	return nil
+}
+
+// AddImagesFromBytes appends a file named name containing data to images, it is uploaded on save (accepted mime types: image/png)
+func (a *PostsRecord) AddImagesFromBytes(name string, data []byte) error {
+	file, err := filesystem.NewFileFromBytes(data, name)
+	if err != nil { return err }
+	a.Set("images+", file)
+	return nil
+}
+
+// DeleteImages removes the files named names from images, they are deleted from the storage on save
+func (a *PostsRecord) DeleteImages(names ...string) {
+	a.Set("images-", names)
+}
+
+func (a *PostsRecord) Meta() any {
+    return a.Get("meta")
+}
+
+
+func (a *PostsRecord) SetMeta(meta any) {
+    a.Set("meta", meta)
+}
+
+// OriginalMeta returns meta as it was loaded from the database, the zero value for new records
+func (a *PostsRecord) OriginalMeta() any {
+	return a.Original().Get("meta")
+}
+
+// MetaChanged reports whether meta differs from the value loaded from the database
+func (a *PostsRecord) MetaChanged() bool {
+	return jsonChanged(a.OriginalMeta(), a.Meta())
+}
+
+func (a *PostsRecord) Website() string {
+    return a.GetString("website")
+}
+
+
+func (a *PostsRecord) SetWebsite(website string) {
+    a.Set("website", website)
+}
+
+// OriginalWebsite returns website as it was loaded from the database, the zero value for new records
+func (a *PostsRecord) OriginalWebsite() string {
+	return a.Original().GetString("website")
+}
+
+// WebsiteChanged reports whether website differs from the value loaded from the database
+func (a *PostsRecord) WebsiteChanged() bool {
+	return a.OriginalWebsite() != a.Website()
+}
+
+func (a *PostsRecord) Contact() string {
+    return a.GetString("contact")
+}
+
+
+func (a *PostsRecord) SetContact(contact string) {
+    a.Set("contact", contact)
+}
+
+// OriginalContact returns contact as it was loaded from the database, the zero value for new records
+func (a *PostsRecord) OriginalContact() string {
+	return a.Original().GetString("contact")
+}
+
+// ContactChanged reports whether contact differs from the value loaded from the database
+func (a *PostsRecord) ContactChanged() bool {
+	return a.OriginalContact() != a.Contact()
+}
+
+func (a *PostsRecord) PublishedAt() types.DateTime {
+    return a.GetDateTime("published_at")
+}
+
+
+
+// OriginalPublishedAt returns published_at as it was loaded from the database, the zero value for new records
+func (a *PostsRecord) OriginalPublishedAt() types.DateTime {
+	return a.Original().GetDateTime("published_at")
+}
+
+// PublishedAtChanged reports whether published_at differs from the value loaded from the database
+func (a *PostsRecord) PublishedAtChanged() bool {
+	return !a.OriginalPublishedAt().Equal(a.PublishedAt())
+}
+
+func (a *PostsRecord) Due() types.DateTime {
+    return a.GetDateTime("due")
+}
+
+
+func (a *PostsRecord) SetDue(due types.DateTime) {
+    a.Set("due", due)
+}
+
+// OriginalDue returns due as it was loaded from the database, the zero value for new records
+func (a *PostsRecord) OriginalDue() types.DateTime {
+	return a.Original().GetDateTime("due")
+}
+
+// DueChanged reports whether due differs from the value loaded from the database
+func (a *PostsRecord) DueChanged() bool {
+	return !a.OriginalDue().Equal(a.Due())
+}
+
+func (a *PostsRecord) Category() string {
+    return a.GetString("category")
+}
+
+
+func (a *PostsRecord) SetCategory(category string) {
+    a.Set("category", category)
+}
+
+// OriginalCategory returns category as it was loaded from the database, the zero value for new records
+func (a *PostsRecord) OriginalCategory() string {
+	return a.Original().GetString("category")
+}
+
+// CategoryChanged reports whether category differs from the value loaded from the database
+func (a *PostsRecord) CategoryChanged() bool {
+	return a.OriginalCategory() != a.Category()
+}
+
+func (a *PostsRecord) ExpandCategory(app core.App) (*CategoriesRecord, error) {
+	if errs := app.ExpandRecord(a.Record, []string{"category"}, nil); len(errs) > 0 {
+		return nil, errs["category"]
+	}
+	return a.ExpandedCategory(), nil
+}
+
+// ExpandedCategory returns the already expanded category relation without querying the database
+func (a *PostsRecord) ExpandedCategory() *CategoriesRecord {
+	record := a.ExpandedOne("category")
+	if record == nil { return nil }
+	return Categories_Wrap(record)
+}
+
+func (a *PostsRecord) Created() types.DateTime {
+    return a.GetDateTime("created")
+}
+
+
+
+// OriginalCreated returns created as it was loaded from the database, the zero value for new records
+func (a *PostsRecord) OriginalCreated() types.DateTime {
+	return a.Original().GetDateTime("created")
+}
+
+// CreatedChanged reports whether created differs from the value loaded from the database
+func (a *PostsRecord) CreatedChanged() bool {
+	return !a.OriginalCreated().Equal(a.Created())
+}
+
+func (a *PostsRecord) Updated() types.DateTime {
+    return a.GetDateTime("updated")
+}
+
+
+
+// OriginalUpdated returns updated as it was loaded from the database, the zero value for new records
+func (a *PostsRecord) OriginalUpdated() types.DateTime {
+	return a.Original().GetDateTime("updated")
+}
+
+// UpdatedChanged reports whether updated differs from the value loaded from the database
+func (a *PostsRecord) UpdatedChanged() bool {
+	return !a.OriginalUpdated().Equal(a.Updated())
+}
+
+
+// CategoriesViaFeatured returns all categories records referencing this record in their featured field, filter is optional
+func (a *PostsRecord) CategoriesViaFeatured(app core.App, filter string, params ...dbx.Params) ([]*CategoriesRecord, error) {
+	_filter := "featured = {:viaRecordId}"
+	if filter != "" { _filter += " && (" + filter + ")" }
+	_params := dbx.Params{"viaRecordId": a.Record.Id}
+	for _, p := range params {
+		for k, v := range p { _params[k] = v }
+	}
+	return Categories_FindRecordsByFilter(app, _filter, "", 0, 0, _params)
+}
+
+
+
+// PublicExportStruct converts the record with a json round trip of PublicExport, conversion errors result in
+// zero fields. Prefer ToStruct.
+func (a *PostsRecord) PublicExportStruct() PostsStruct {
+	bytes, _ := json.Marshal(a.PublicExport())
+	var record = PostsStruct{}
+	_ = json.Unmarshal(bytes, &record)
+	return record
+}
+
+// ChangedFields returns the names of the fields which differ from the values loaded from the database, all fields
+// with a value are changed for new records
+func (a *PostsRecord) ChangedFields() []string {
+	var fields []string
+	if a.IdChanged() { fields = append(fields, PostsFields.Id) }
+	if a.TitleChanged() { fields = append(fields, PostsFields.Title) }
+	if a.SlugChanged() { fields = append(fields, PostsFields.Slug) }
+	if a.BodyChanged() { fields = append(fields, PostsFields.Body) }
+	if a.ViewsChanged() { fields = append(fields, PostsFields.Views) }
+	if a.RatingChanged() { fields = append(fields, PostsFields.Rating) }
+	if a.PublishedChanged() { fields = append(fields, PostsFields.Published) }
+	if a.StatusChanged() { fields = append(fields, PostsFields.Status) }
+	if a.TagsChanged() { fields = append(fields, PostsFields.Tags) }
+	if a.AuthorChanged() { fields = append(fields, PostsFields.Author) }
+	if a.ReviewersChanged() { fields = append(fields, PostsFields.Reviewers) }
+	if a.ImagesChanged() { fields = append(fields, PostsFields.Images) }
+	if a.MetaChanged() { fields = append(fields, PostsFields.Meta) }
+	if a.WebsiteChanged() { fields = append(fields, PostsFields.Website) }
+	if a.ContactChanged() { fields = append(fields, PostsFields.Contact) }
+	if a.PublishedAtChanged() { fields = append(fields, PostsFields.PublishedAt) }
+	if a.DueChanged() { fields = append(fields, PostsFields.Due) }
+	if a.CategoryChanged() { fields = append(fields, PostsFields.Category) }
+	if a.CreatedChanged() { fields = append(fields, PostsFields.Created) }
+	if a.UpdatedChanged() { fields = append(fields, PostsFields.Updated) }
+	return fields
+}
+
+// ToStruct converts the record and its expanded relations field by field, it is a faster replacement of
+// PublicExportStruct which also reports json fields which can not be converted.
+func (a *PostsRecord) ToStruct() (PostsStruct, error) {
+	s := PostsStruct{
+		Id: a.Id(),
+		Title: a.Title(),
+		Slug: a.Slug(),
+		Body: ptr(a.Body()),
+		Views: ptr(float32(a.Views())),
+		Rating: float32(a.Rating()),
+		Published: ptr(a.Published()),
+		Status: PostsStatusOptions(a.Status()),
+		Tags: ptr(convertStrings[PostsTagsOptions](a.Tags())),
+		Author: a.Author(),
+		Reviewers: ptr(a.Reviewers()),
+		Images: ptr(a.Images()),
+		Website: ptr(a.Website()),
+		Contact: ptr(a.Contact()),
+		PublishedAt: ptr(a.PublishedAt().String()),
+		Due: ptr(a.Due().String()),
+		Category: a.Category(),
+		Created: ptr(a.Created().String()),
+		Updated: ptr(a.Updated().String()),
+	}
+
+	if record := a.ExpandedAuthor(); record != nil {
+		value, err := record.ToStruct()
+		if err != nil { return s, err }
+		s.Expand.Author = value
+	}
+	if records := a.ExpandedReviewers(); len(records) > 0 {
+		values := make([]UsersStruct, len(records))
+		for i, record := range records {
+			value, err := record.ToStruct()
+			if err != nil { return s, err }
+			values[i] = value
+		}
+		s.Expand.Reviewers = &values
+	}
+	if value, err := structJSON("meta", a.Meta()); err != nil {
+		return s, err
+	} else if value != nil {
+		s.Meta = &value
+	}
+	if record := a.ExpandedCategory(); record != nil {
+		value, err := record.ToStruct()
+		if err != nil { return s, err }
+		s.Expand.Category = &value
+	}
+
+	return s, nil
+}
+
+// Posts_FromStruct creates an unsaved record with the fields of s, s.Expand is ignored
+func Posts_FromStruct(app core.App, s PostsStruct) (*PostsRecord, error) {
+	c, err := app.FindCollectionByNameOrId(CollectionPosts)
+	if err != nil { return nil, err }
+
+	record := core.NewRecord(c)
+	record.Set("id", s.Id)
+	record.Set("title", s.Title)
+	record.Set("slug", s.Slug)
+	record.Set("body", deref(s.Body))
+	record.Set("views", deref(s.Views))
+	record.Set("rating", s.Rating)
+	record.Set("published", deref(s.Published))
+	record.Set("status", string(s.Status))
+	record.Set("tags", nonEmpty(deref(s.Tags)...))
+	record.Set("author", s.Author)
+	record.Set("reviewers", deref(s.Reviewers))
+	record.Set("images", deref(s.Images))
+	record.Set("meta", deref(s.Meta))
+	record.Set("website", deref(s.Website))
+	record.Set("contact", deref(s.Contact))
+	if value, err := types.ParseDateTime(deref(s.PublishedAt)); err == nil { record.SetRaw("published_at", value) }
+	record.Set("due", deref(s.Due))
+	record.Set("category", s.Category)
+	if value, err := types.ParseDateTime(deref(s.Created)); err == nil { record.SetRaw("created", value) }
+	if value, err := types.ParseDateTime(deref(s.Updated)); err == nil { record.SetRaw("updated", value) }
+
+	return Posts_Wrap(record), nil
+}
+
+// Save validates and saves the record, validation errors are returned as *ValidationError
+func (a *PostsRecord) Save(app core.App) error {
+	return newRecordError(CollectionPosts, app.Save(a))
+}
+
+// Delete deletes the record together with the records referencing it with cascade delete. It fails if the record is
+// still referenced by a required relation without cascade delete, validation errors of hooks are returned as
+// *ValidationError.
+func (a *PostsRecord) Delete(app core.App) error {
+	return newRecordError(CollectionPosts, app.Delete(a))
+}
+
+// Validate validates the record without saving it, validation errors are returned as *ValidationError
+func (a *PostsRecord) Validate(app core.App) error {
+	return newRecordError(CollectionPosts, app.Validate(a))
+}
+
+// Posts_RunInTransaction runs fn in a database transaction which is rolled back if fn returns an error. Use tx
+// instead of app for all generated helpers called in fn.
+func Posts_RunInTransaction(app core.App, fn func(tx core.App) error) error {
+	return app.RunInTransaction(fn)
+}
+
+type CategoriesExpanded struct {
+    Parent *CategoriesStruct `json:"parent"`;
+    Featured PostsStruct `json:"featured"`;
+}
+
+type CategoriesStruct struct {
+    Expand CategoriesExpanded `json:"expand"`
+    Id string `json:"id"`;
+    Name string `json:"name"`;
+    Parent string `json:"parent"`;
+    Featured string `json:"featured"`;
+}
+
+var CategoriesFields = struct {
+    Id, Name, Parent, Featured string
+}{
+Id: "id",
+Name: "name",
+Parent: "parent",
+Featured: "featured",
+}
+
+// Validate checks the field options of the categories collection without a database, errors are returned as
+// *ValidationError. Relations are not checked for existence and files are only counted, use the
+// Categories_ValidateXxxUpload functions for uploads.
+func (s CategoriesStruct) Validate() error {
+	errs := validation.Errors{}
+	if err := validateText(s.Name, true, 0, 0, ""); err != nil { errs["name"] = err }
+	if err := validateValues(nonEmpty(s.Parent), true, 0, 1, []string(nil)); err != nil { errs["parent"] = err }
+	if err := validateValues(nonEmpty(s.Featured), true, 0, 1, []string(nil)); err != nil { errs["featured"] = err }
+	if len(errs) == 0 { return nil }
+	return newRecordError(CollectionCategories, errs)
+}
+
+// CategoriesCreate holds the writable fields of a new categories record, optional fields are only set if they are not nil
+type CategoriesCreate struct {
+    Name string `json:"name"`
+    Parent string `json:"parent"`
+    Featured string `json:"featured"`
+}
+
+// ApplyTo sets the fields on record, use it with the record returned by Categories_New
+func (d CategoriesCreate) ApplyTo(record *CategoriesRecord) {
+	record.Set("name", d.Name)
+	record.Set("parent", d.Parent)
+	record.Set("featured", d.Featured)
+}
+
+// CategoriesUpdate holds the writable fields of a categories record for patching, only fields which are not nil are changed
+type CategoriesUpdate struct {
+    Name *string `json:"name,omitempty"`
+    Parent *string `json:"parent,omitempty"`
+    Featured *string `json:"featured,omitempty"`
+}
+
+// ApplyTo sets the fields which are not nil on record
+func (d CategoriesUpdate) ApplyTo(record *CategoriesRecord) {
+	if d.Name != nil { record.Set("name", *d.Name) }
+	if d.Parent != nil { record.Set("parent", *d.Parent) }
+	if d.Featured != nil { record.Set("featured", *d.Featured) }
+}
+
+var CategoriesFilter = struct {
+    Id StringFilterField
+    Name StringFilterField
+    Parent RelationFilterField
+    Featured RelationFilterField
+}{
+    Id: StringFilterField{name: "id"},
+    Name: StringFilterField{name: "name"},
+    Parent: RelationFilterField{name: "parent"},
+    Featured: RelationFilterField{name: "featured"},
+}
+
+func Categories_FindRecordsWhere(app core.App, filter Filter, sort string, limit int, offset int) ([]*CategoriesRecord, error) {
+	expr, params := filter.Build()
+	return Categories_FindRecordsByFilter(app, expr, sort, limit, offset, params)
+}
+
+var CategoriesSort = struct {
+    Id SortField
+    Name SortField
+    Parent SortField
+    Featured SortField
+}{
+    Id: SortField{name: "id"},
+    Name: SortField{name: "name"},
+    Parent: SortField{name: "parent"},
+    Featured: SortField{name: "featured"},
+}
+
+func Categories_List(app core.App, opts ListOptions) (ListResult[*CategoriesRecord], error) {
+	page, perPage := opts.pagination()
+	expr, params := opts.Filter.Build()
+	total, err := countRecordsByFilter(app, CollectionCategories, expr, params)
+	if err != nil { return ListResult[*CategoriesRecord]{}, err }
+	records, err := Categories_FindRecordsByFilter(app, expr, opts.sort(), perPage, (page-1)*perPage, params)
+	if err != nil { return ListResult[*CategoriesRecord]{}, err }
+	return newListResult(records, page, perPage, total), nil
+}
+
+// Categories_All iterates over all records matching filter without loading them at once, see AllBatchSize
+func Categories_All(app core.App, filter string, params ...dbx.Params) iter.Seq2[*CategoriesRecord, error] {
+	return func(yield func(*CategoriesRecord, error) bool) {
+		for _record, err := range allRecords(app, CollectionCategories, filter, params...) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(Categories_Wrap(_record), nil) { return }
+		}
+	}
+}
+
+const (
+    CategoriesExpandPostsViaCategory = "posts_via_category"
+    CategoriesExpandCategoriesViaParent = "categories_via_parent"
+)
+
+
+var _ core.RecordProxy = (*CategoriesRecord)(nil)
+
+type CategoriesRecord struct {
+    core.BaseRecordProxy
+}
+
+func (a *CategoriesRecord) Id() string {
+    return a.GetString("id")
+}
+
+
+
+// OriginalId returns id as it was loaded from the database, the zero value for new records
+func (a *CategoriesRecord) OriginalId() string {
+	return a.Original().GetString("id")
+}
+
+// IdChanged reports whether id differs from the value loaded from the database
+func (a *CategoriesRecord) IdChanged() bool {
+	return a.OriginalId() != a.Id()
+}
+
+func (a *CategoriesRecord) Name() string {
+    return a.GetString("name")
+}
+
+
+func (a *CategoriesRecord) SetName(name string) {
+    a.Set("name", name)
+}
+
+// OriginalName returns name as it was loaded from the database, the zero value for new records
+func (a *CategoriesRecord) OriginalName() string {
+	return a.Original().GetString("name")
+}
+
+// NameChanged reports whether name differs from the value loaded from the database
+func (a *CategoriesRecord) NameChanged() bool {
+	return a.OriginalName() != a.Name()
+}
+
+func (a *CategoriesRecord) Parent() string {
+    return a.GetString("parent")
+}
+
+
+func (a *CategoriesRecord) SetParent(parent string) {
+    a.Set("parent", parent)
+}
+
+// OriginalParent returns parent as it was loaded from the database, the zero value for new records
+func (a *CategoriesRecord) OriginalParent() string {
+	return a.Original().GetString("parent")
+}
+
+// ParentChanged reports whether parent differs from the value loaded from the database
+func (a *CategoriesRecord) ParentChanged() bool {
+	return a.OriginalParent() != a.Parent()
+}
+
+func (a *CategoriesRecord) ExpandParent(app core.App) (*CategoriesRecord, error) {
+	if errs := app.ExpandRecord(a.Record, []string{"parent"}, nil); len(errs) > 0 {
+		return nil, errs["parent"]
+	}
+	return a.ExpandedParent(), nil
+}
+
+// ExpandedParent returns the already expanded parent relation without querying the database
+func (a *CategoriesRecord) ExpandedParent() *CategoriesRecord {
+	record := a.ExpandedOne("parent")
+	if record == nil { return nil }
+	return Categories_Wrap(record)
+}
+
+func (a *CategoriesRecord) Featured() string {
+    return a.GetString("featured")
+}
+
+
+func (a *CategoriesRecord) SetFeatured(featured string) {
+    a.Set("featured", featured)
+}
+
+// OriginalFeatured returns featured as it was loaded from the database, the zero value for new records
+func (a *CategoriesRecord) OriginalFeatured() string {
+	return a.Original().GetString("featured")
+}
+
+// FeaturedChanged reports whether featured differs from the value loaded from the database
+func (a *CategoriesRecord) FeaturedChanged() bool {
+	return a.OriginalFeatured() != a.Featured()
+}
+
+func (a *CategoriesRecord) ExpandFeatured(app core.App) (*PostsRecord, error) {
+	if errs := app.ExpandRecord(a.Record, []string{"featured"}, nil); len(errs) > 0 {
+		return nil, errs["featured"]
+	}
+	return a.ExpandedFeatured(), nil
+}
+
+// ExpandedFeatured returns the already expanded featured relation without querying the database
+func (a *CategoriesRecord) ExpandedFeatured() *PostsRecord {
+	record := a.ExpandedOne("featured")
+	if record == nil { return nil }
+	return Posts_Wrap(record)
+}
+
+
+// PostsViaCategory returns all posts records referencing this record in their category field, filter is optional
+func (a *CategoriesRecord) PostsViaCategory(app core.App, filter string, params ...dbx.Params) ([]*PostsRecord, error) {
+	_filter := "category = {:viaRecordId}"
+	if filter != "" { _filter += " && (" + filter + ")" }
+	_params := dbx.Params{"viaRecordId": a.Record.Id}
+	for _, p := range params {
+		for k, v := range p { _params[k] = v }
+	}
+	return Posts_FindRecordsByFilter(app, _filter, "", 0, 0, _params)
+}
+
+
+// CategoriesViaParent returns all categories records referencing this record in their parent field, filter is optional
+func (a *CategoriesRecord) CategoriesViaParent(app core.App, filter string, params ...dbx.Params) ([]*CategoriesRecord, error) {
+	_filter := "parent = {:viaRecordId}"
+	if filter != "" { _filter += " && (" + filter + ")" }
+	_params := dbx.Params{"viaRecordId": a.Record.Id}
+	for _, p := range params {
+		for k, v := range p { _params[k] = v }
+	}
+	return Categories_FindRecordsByFilter(app, _filter, "", 0, 0, _params)
+}
+
+
+
+// PublicExportStruct converts the record with a json round trip of PublicExport, conversion errors result in
+// zero fields. Prefer ToStruct.
+func (a *CategoriesRecord) PublicExportStruct() CategoriesStruct {
+	bytes, _ := json.Marshal(a.PublicExport())
+	var record = CategoriesStruct{}
+	_ = json.Unmarshal(bytes, &record)
+	return record
+}
+
+// ChangedFields returns the names of the fields which differ from the values loaded from the database, all fields
+// with a value are changed for new records
+func (a *CategoriesRecord) ChangedFields() []string {
+	var fields []string
+	if a.IdChanged() { fields = append(fields, CategoriesFields.Id) }
+	if a.NameChanged() { fields = append(fields, CategoriesFields.Name) }
+	if a.ParentChanged() { fields = append(fields, CategoriesFields.Parent) }
+	if a.FeaturedChanged() { fields = append(fields, CategoriesFields.Featured) }
+	return fields
+}
+
+// ToStruct converts the record and its expanded relations field by field, it is a faster replacement of
+// PublicExportStruct which also reports json fields which can not be converted.
+func (a *CategoriesRecord) ToStruct() (CategoriesStruct, error) {
+	s := CategoriesStruct{
+		Id: a.Id(),
+		Name: a.Name(),
+		Parent: a.Parent(),
+		Featured: a.Featured(),
+	}
+
+	if record := a.ExpandedParent(); record != nil {
+		value, err := record.ToStruct()
+		if err != nil { return s, err }
+		s.Expand.Parent = &value
+	}
+	if record := a.ExpandedFeatured(); record != nil {
+		value, err := record.ToStruct()
+		if err != nil { return s, err }
+		s.Expand.Featured = value
+	}
+
+	return s, nil
+}
+
+// Categories_FromStruct creates an unsaved record with the fields of s, s.Expand is ignored
+func Categories_FromStruct(app core.App, s CategoriesStruct) (*CategoriesRecord, error) {
+	c, err := app.FindCollectionByNameOrId(CollectionCategories)
+	if err != nil { return nil, err }
+
+	record := core.NewRecord(c)
+	record.Set("id", s.Id)
+	record.Set("name", s.Name)
+	record.Set("parent", s.Parent)
+	record.Set("featured", s.Featured)
+
+	return Categories_Wrap(record), nil
+}
+
+// Save validates and saves the record, validation errors are returned as *ValidationError
+func (a *CategoriesRecord) Save(app core.App) error {
+	return newRecordError(CollectionCategories, app.Save(a))
+}
+
+// Delete deletes the record together with the records referencing it with cascade delete. It fails if the record is
+// still referenced by a required relation without cascade delete, validation errors of hooks are returned as
+// *ValidationError.
+func (a *CategoriesRecord) Delete(app core.App) error {
+	return newRecordError(CollectionCategories, app.Delete(a))
+}
+
+// Validate validates the record without saving it, validation errors are returned as *ValidationError
+func (a *CategoriesRecord) Validate(app core.App) error {
+	return newRecordError(CollectionCategories, app.Validate(a))
+}
+
+// Categories_RunInTransaction runs fn in a database transaction which is rolled back if fn returns an error. Use tx
+// instead of app for all generated helpers called in fn.
+func Categories_RunInTransaction(app core.App, fn func(tx core.App) error) error {
+	return app.RunInTransaction(fn)
+}
+
+type PostStatsStruct struct {
+    Id string `json:"id"`;
+    Title *string `json:"title"`;
+    Views *float32 `json:"views"`;
+}
+
+var PostStatsFields = struct {
+    Id, Title, Views string
+}{
+Id: "id",
+Title: "title",
+Views: "views",
+}
+
+var PostStatsFilter = struct {
+    Id StringFilterField
+    Title StringFilterField
+    Views NumberFilterField
+}{
+    Id: StringFilterField{name: "id"},
+    Title: StringFilterField{name: "title"},
+    Views: NumberFilterField{name: "views"},
+}
+
+func PostStats_FindRecordsWhere(app core.App, filter Filter, sort string, limit int, offset int) ([]*PostStatsRecord, error) {
+	expr, params := filter.Build()
+	return PostStats_FindRecordsByFilter(app, expr, sort, limit, offset, params)
+}
+
+var PostStatsSort = struct {
+    Id SortField
+    Title SortField
+    Views SortField
+}{
+    Id: SortField{name: "id"},
+    Title: SortField{name: "title"},
+    Views: SortField{name: "views"},
+}
+
+func PostStats_List(app core.App, opts ListOptions) (ListResult[*PostStatsRecord], error) {
+	page, perPage := opts.pagination()
+	expr, params := opts.Filter.Build()
+	total, err := countRecordsByFilter(app, CollectionPostStats, expr, params)
+	if err != nil { return ListResult[*PostStatsRecord]{}, err }
+	records, err := PostStats_FindRecordsByFilter(app, expr, opts.sort(), perPage, (page-1)*perPage, params)
+	if err != nil { return ListResult[*PostStatsRecord]{}, err }
+	return newListResult(records, page, perPage, total), nil
+}
+
+// PostStats_All iterates over all records matching filter without loading them at once, see AllBatchSize
+func PostStats_All(app core.App, filter string, params ...dbx.Params) iter.Seq2[*PostStatsRecord, error] {
+	return func(yield func(*PostStatsRecord, error) bool) {
+		for _record, err := range allRecords(app, CollectionPostStats, filter, params...) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(PostStats_Wrap(_record), nil) { return }
+		}
+	}
+}
+
+var _ core.RecordProxy = (*PostStatsRecord)(nil)
+
+// PostStatsRecord is a read-only record of the post_stats view collection:
+//
+//	SELECT id, title, views FROM posts
+type PostStatsRecord struct {
+    core.BaseRecordProxy
+}
+
+func (a *PostStatsRecord) Id() string {
+    return a.GetString("id")
+}
+
+
+
+func (a *PostStatsRecord) Title() string {
+    return a.GetString("title")
+}
+
+
+
+func (a *PostStatsRecord) ViewsInt() int {
+    return a.GetInt("views")
+}
+
+func (a *PostStatsRecord) Views() float64 {
+    return a.GetFloat("views")
+}
+
+
+
+
+
+// PublicExportStruct converts the record with a json round trip of PublicExport, conversion errors result in
+// zero fields. Prefer ToStruct.
+func (a *PostStatsRecord) PublicExportStruct() PostStatsStruct {
+	bytes, _ := json.Marshal(a.PublicExport())
+	var record = PostStatsStruct{}
+	_ = json.Unmarshal(bytes, &record)
+	return record
+}
+
+// ToStruct converts the record and its expanded relations field by field, it is a faster replacement of
+// PublicExportStruct which also reports json fields which can not be converted.
+func (a *PostStatsRecord) ToStruct() (PostStatsStruct, error) {
+	s := PostStatsStruct{
+		Id: a.Id(),
+		Title: ptr(a.Title()),
+		Views: ptr(float32(a.Views())),
+	}
+
+
+	return s, nil
+}
+
+const (
+    CollectionUsers = "users"
+    CollectionPosts = "posts"
+    CollectionCategories = "categories"
+    CollectionPostStats = "post_stats"
+)
+
+func Users_Wrap(record *core.Record) *UsersRecord {
+	typedRecord := &UsersRecord{}
+	typedRecord.SetProxyRecord(record)
+	return typedRecord
+}
+
+func Users_FindRecordById(app core.App, recordId string, optFilters ...func(q *dbx.SelectQuery) error) (*UsersRecord, error) {
+	_record, err := app.FindRecordById(CollectionUsers, recordId, optFilters...)
+	if err != nil { return nil, err }
+	return Users_Wrap(_record), nil
+}
+
+func Users_FindFirstRecordByData(app core.App, key string, value any) (*UsersRecord, error) {
+	_record, err := app.FindFirstRecordByData(CollectionUsers, key, value)
+	if err != nil { return nil, err }
+	return Users_Wrap(_record), nil
+}
+
+func Users_FindRecordsByFilter(app core.App,
+	filter string,
+	sort string,
+	limit int,
+	offset int,
+	params ...dbx.Params) ([]*UsersRecord, error) {
+	_records, err := app.FindRecordsByFilter(CollectionUsers, filter, sort, limit, offset, params...)
+	if err != nil { return nil, err }
+	records := make([]*UsersRecord, len(_records))
+	for i, _record := range _records { records[i] = Users_Wrap(_record) }
+	return records, err
+}
+	
+func Users_New(app core.App) (*UsersRecord, error) {
+	c, err := app.FindCollectionByNameOrId(CollectionUsers)
+	if err != nil { return nil, err }
+	return Users_Wrap(core.NewRecord(c)), nil
+}
+
+// Users_FindByEmail returns the record matching the unique index idx_email__pb_users_auth_
+func Users_FindByEmail(app core.App, email string) (*UsersRecord, error) {
+	_record, err := app.FindFirstRecordByFilter(CollectionUsers, "email = {:p0}", dbx.Params{"p0": email})
+	if err != nil { return nil, err }
+	return Users_Wrap(_record), nil
+}
+
+// UsersRepository bundles the data access to UsersRecord, services can depend on it instead of core.App
+type UsersRepository interface {
+	Find(id string) (*UsersRecord, error)
+	List(opts ListOptions) (ListResult[*UsersRecord], error)
+	Count(filter Filter) (int64, error)
+	Exists(id string) (bool, error)
+	Save(record *UsersRecord) error
+	Delete(record *UsersRecord) error
+}
+
+type usersRepo struct {
+	app core.App
+}
+
+var _ UsersRepository = (*usersRepo)(nil)
+
+func NewUsersRepo(app core.App) UsersRepository {
+	return &usersRepo{app: app}
+}
+
+func (r *usersRepo) Find(id string) (*UsersRecord, error) {
+	return Users_FindRecordById(r.app, id)
+}
+
+func (r *usersRepo) List(opts ListOptions) (ListResult[*UsersRecord], error) {
+	return Users_List(r.app, opts)
+}
+
+func (r *usersRepo) Count(filter Filter) (int64, error) {
+	expr, params := filter.Build()
+	return countRecordsByFilter(r.app, CollectionUsers, expr, params)
+}
+
+func (r *usersRepo) Exists(id string) (bool, error) {
+	total, err := countRecordsByFilter(r.app, CollectionUsers, "id = {:id}", dbx.Params{"id": id})
+	return total > 0, err
+}
+
+func (r *usersRepo) Save(record *UsersRecord) error {
+	return record.Save(r.app)
+}
+
+func (r *usersRepo) Delete(record *UsersRecord) error {
+	return record.Delete(r.app)
+}
+
+// FakeUsersRepo is an in-memory UsersRepository for unit tests of services which should run without a database.
+// Filters and sort orders are evaluated in go, relations are not resolved.
+type FakeUsersRepo struct {
+	store *fakeStore
+}
+
+var _ UsersRepository = (*FakeUsersRepo)(nil)
+
+// NewFakeUsersRepo creates a FakeUsersRepo containing records, records without id get a random one
+func NewFakeUsersRepo(records ...*UsersRecord) (*FakeUsersRepo, error) {
+	r := &FakeUsersRepo{store: newFakeStore(newFakeUsersCollection())}
+	for _, record := range records {
+		if err := r.store.save(record.ProxyRecord()); err != nil { return nil, err }
+	}
+	return r, nil
+}
+
+func (r *FakeUsersRepo) Find(id string) (*UsersRecord, error) {
+	record, err := r.store.find(id)
+	if err != nil { return nil, err }
+	return Users_Wrap(record), nil
+}
+
+func (r *FakeUsersRepo) List(opts ListOptions) (ListResult[*UsersRecord], error) {
+	_records, page, perPage, total := r.store.list(opts)
+	records := make([]*UsersRecord, len(_records))
+	for i, _record := range _records { records[i] = Users_Wrap(_record) }
+	return newListResult(records, page, perPage, total), nil
+}
+
+func (r *FakeUsersRepo) Count(filter Filter) (int64, error) {
+	return int64(len(r.store.filter(filter))), nil
+}
+
+func (r *FakeUsersRepo) Exists(id string) (bool, error) {
+	_, ok := r.store.records[id]
+	return ok, nil
+}
+
+func newFakeUsersCollection() *core.Collection {
+	collection := core.NewCollection("auth", CollectionUsers)
+	collection.Fields.Add(
+		&core.EmailField{Name: "email"},
+		&core.BoolField{Name: "emailVisibility"},
+		&core.BoolField{Name: "verified"},
+		&core.TextField{Name: "name"},
+		&core.FileField{Name: "avatar", MaxSelect: 1},
+		&core.AutodateField{Name: "created", OnCreate: true, OnUpdate: false},
+		&core.AutodateField{Name: "updated", OnCreate: true, OnUpdate: true},
+	)
+	return collection
+}
+
+// New creates an unsaved record of the fake collection, use it instead of Users_New which needs an app
+func (r *FakeUsersRepo) New() *UsersRecord {
+	return Users_Wrap(core.NewRecord(r.store.collection))
+}
+
+func (r *FakeUsersRepo) Save(record *UsersRecord) error {
+	return r.store.save(record.ProxyRecord())
+}
+
+func (r *FakeUsersRepo) Delete(record *UsersRecord) error {
+	return r.store.delete(record.ProxyRecord().Id)
+}
+
+// UsersRecordEvent is a core.RecordEvent with the typed record, call e.Next() in handlers to continue the hook chain
+type UsersRecordEvent struct {
+	*core.RecordEvent
+	Record *UsersRecord
+}
+
+// UsersRecordErrorEvent is a core.RecordErrorEvent with the typed record, call e.Next() in handlers to continue the hook chain
+type UsersRecordErrorEvent struct {
+	*core.RecordErrorEvent
+	Record *UsersRecord
+}
+
+// UsersRecordRequestEvent is a core.RecordRequestEvent with the typed record, call e.Next() in handlers to continue the hook chain
+type UsersRecordRequestEvent struct {
+	*core.RecordRequestEvent
+	Record *UsersRecord
+}
+
+// Users_OnValidate binds handler to app.OnRecordValidate of the users collection and returns the handler id
+func Users_OnValidate(app core.App, handler func(e *UsersRecordEvent) error) string {
+	return app.OnRecordValidate(CollectionUsers).BindFunc(func(e *core.RecordEvent) error {
+		return handler(&UsersRecordEvent{RecordEvent: e, Record: Users_Wrap(e.Record)})
+	})
+}
+
+// Users_OnCreate binds handler to app.OnRecordCreate of the users collection and returns the handler id
+func Users_OnCreate(app core.App, handler func(e *UsersRecordEvent) error) string {
+	return app.OnRecordCreate(CollectionUsers).BindFunc(func(e *core.RecordEvent) error {
+		return handler(&UsersRecordEvent{RecordEvent: e, Record: Users_Wrap(e.Record)})
+	})
+}
+
+// Users_OnCreateExecute binds handler to app.OnRecordCreateExecute of the users collection and returns the handler id
+func Users_OnCreateExecute(app core.App, handler func(e *UsersRecordEvent) error) string {
+	return app.OnRecordCreateExecute(CollectionUsers).BindFunc(func(e *core.RecordEvent) error {
+		return handler(&UsersRecordEvent{RecordEvent: e, Record: Users_Wrap(e.Record)})
+	})
+}
+
+// Users_OnAfterCreateSuccess binds handler to app.OnRecordAfterCreateSuccess of the users collection and returns the handler id
+func Users_OnAfterCreateSuccess(app core.App, handler func(e *UsersRecordEvent) error) string {
+	return app.OnRecordAfterCreateSuccess(CollectionUsers).BindFunc(func(e *core.RecordEvent) error {
+		return handler(&UsersRecordEvent{RecordEvent: e, Record: Users_Wrap(e.Record)})
+	})
+}
+
+// Users_OnAfterCreateError binds handler to app.OnRecordAfterCreateError of the users collection and returns the handler id
+func Users_OnAfterCreateError(app core.App, handler func(e *UsersRecordErrorEvent) error) string {
+	return app.OnRecordAfterCreateError(CollectionUsers).BindFunc(func(e *core.RecordErrorEvent) error {
+		return handler(&UsersRecordErrorEvent{RecordErrorEvent: e, Record: Users_Wrap(e.Record)})
+	})
+}
+
+// Users_OnUpdate binds handler to app.OnRecordUpdate of the users collection and returns the handler id
+func Users_OnUpdate(app core.App, handler func(e *UsersRecordEvent) error) string {
+	return app.OnRecordUpdate(CollectionUsers).BindFunc(func(e *core.RecordEvent) error {
+		return handler(&UsersRecordEvent{RecordEvent: e, Record: Users_Wrap(e.Record)})
+	})
+}
+
+// Users_OnUpdateExecute binds handler to app.OnRecordUpdateExecute of the users collection and returns the handler id
+func Users_OnUpdateExecute(app core.App, handler func(e *UsersRecordEvent) error) string {
+	return app.OnRecordUpdateExecute(CollectionUsers).BindFunc(func(e *core.RecordEvent) error {
+		return handler(&UsersRecordEvent{RecordEvent: e, Record: Users_Wrap(e.Record)})
+	})
+}
+
+// Users_OnAfterUpdateSuccess binds handler to app.OnRecordAfterUpdateSuccess of the users collection and returns the handler id
+func Users_OnAfterUpdateSuccess(app core.App, handler func(e *UsersRecordEvent) error) string {
+	return app.OnRecordAfterUpdateSuccess(CollectionUsers).BindFunc(func(e *core.RecordEvent) error {
+		return handler(&UsersRecordEvent{RecordEvent: e, Record: Users_Wrap(e.Record)})
+	})
+}
+
+// Users_OnAfterUpdateError binds handler to app.OnRecordAfterUpdateError of the users collection and returns the handler id
+func Users_OnAfterUpdateError(app core.App, handler func(e *UsersRecordErrorEvent) error) string {
+	return app.OnRecordAfterUpdateError(CollectionUsers).BindFunc(func(e *core.RecordErrorEvent) error {
+		return handler(&UsersRecordErrorEvent{RecordErrorEvent: e, Record: Users_Wrap(e.Record)})
+	})
+}
+
+// Users_OnDelete binds handler to app.OnRecordDelete of the users collection and returns the handler id
+func Users_OnDelete(app core.App, handler func(e *UsersRecordEvent) error) string {
+	return app.OnRecordDelete(CollectionUsers).BindFunc(func(e *core.RecordEvent) error {
+		return handler(&UsersRecordEvent{RecordEvent: e, Record: Users_Wrap(e.Record)})
+	})
+}
+
+// Users_OnDeleteExecute binds handler to app.OnRecordDeleteExecute of the users collection and returns the handler id
+func Users_OnDeleteExecute(app core.App, handler func(e *UsersRecordEvent) error) string {
+	return app.OnRecordDeleteExecute(CollectionUsers).BindFunc(func(e *core.RecordEvent) error {
+		return handler(&UsersRecordEvent{RecordEvent: e, Record: Users_Wrap(e.Record)})
+	})
+}
+
+// Users_OnAfterDeleteSuccess binds handler to app.OnRecordAfterDeleteSuccess of the users collection and returns the handler id
+func Users_OnAfterDeleteSuccess(app core.App, handler func(e *UsersRecordEvent) error) string {
+	return app.OnRecordAfterDeleteSuccess(CollectionUsers).BindFunc(func(e *core.RecordEvent) error {
+		return handler(&UsersRecordEvent{RecordEvent: e, Record: Users_Wrap(e.Record)})
+	})
+}
+
+// Users_OnAfterDeleteError binds handler to app.OnRecordAfterDeleteError of the users collection and returns the handler id
+func Users_OnAfterDeleteError(app core.App, handler func(e *UsersRecordErrorEvent) error) string {
+	return app.OnRecordAfterDeleteError(CollectionUsers).BindFunc(func(e *core.RecordErrorEvent) error {
+		return handler(&UsersRecordErrorEvent{RecordErrorEvent: e, Record: Users_Wrap(e.Record)})
+	})
+}
+
+// Users_OnViewRequest binds handler to app.OnRecordViewRequest of the users collection and returns the handler id
+func Users_OnViewRequest(app core.App, handler func(e *UsersRecordRequestEvent) error) string {
+	return app.OnRecordViewRequest(CollectionUsers).BindFunc(func(e *core.RecordRequestEvent) error {
+		return handler(&UsersRecordRequestEvent{RecordRequestEvent: e, Record: Users_Wrap(e.Record)})
+	})
+}
+
+// Users_OnCreateRequest binds handler to app.OnRecordCreateRequest of the users collection and returns the handler id
+func Users_OnCreateRequest(app core.App, handler func(e *UsersRecordRequestEvent) error) string {
+	return app.OnRecordCreateRequest(CollectionUsers).BindFunc(func(e *core.RecordRequestEvent) error {
+		return handler(&UsersRecordRequestEvent{RecordRequestEvent: e, Record: Users_Wrap(e.Record)})
+	})
+}
+
+// Users_OnUpdateRequest binds handler to app.OnRecordUpdateRequest of the users collection and returns the handler id
+func Users_OnUpdateRequest(app core.App, handler func(e *UsersRecordRequestEvent) error) string {
+	return app.OnRecordUpdateRequest(CollectionUsers).BindFunc(func(e *core.RecordRequestEvent) error {
+		return handler(&UsersRecordRequestEvent{RecordRequestEvent: e, Record: Users_Wrap(e.Record)})
+	})
+}
+
+// Users_OnDeleteRequest binds handler to app.OnRecordDeleteRequest of the users collection and returns the handler id
+func Users_OnDeleteRequest(app core.App, handler func(e *UsersRecordRequestEvent) error) string {
+	return app.OnRecordDeleteRequest(CollectionUsers).BindFunc(func(e *core.RecordRequestEvent) error {
+		return handler(&UsersRecordRequestEvent{RecordRequestEvent: e, Record: Users_Wrap(e.Record)})
+	})
+}
+
+var _ AuthRecord = (*UsersRecord)(nil)
+
+func Users_FindAuthRecordByEmail(app core.App, email string) (*UsersRecord, error) {
+	_record, err := app.FindAuthRecordByEmail(CollectionUsers, email)
+	if err != nil { return nil, err }
+	return Users_Wrap(_record), nil
+}
+
+// Users_FindAuthRecordByToken finds the record an auth token was issued for, tokens of other collections are rejected
+func Users_FindAuthRecordByToken(app core.App, token string) (*UsersRecord, error) {
+	_record, err := app.FindAuthRecordByToken(token, core.TokenTypeAuth)
+	if err != nil { return nil, err }
+	if _record.Collection().Name != CollectionUsers {
+		return nil, fmt.Errorf("auth token belongs to collection %s instead of %s", _record.Collection().Name, CollectionUsers)
+	}
+	return Users_Wrap(_record), nil
+}
+
+func Posts_Wrap(record *core.Record) *PostsRecord {
+	typedRecord := &PostsRecord{}
+	typedRecord.SetProxyRecord(record)
+	return typedRecord
+}
+
+func Posts_FindRecordById(app core.App, recordId string, optFilters ...func(q *dbx.SelectQuery) error) (*PostsRecord, error) {
+	_record, err := app.FindRecordById(CollectionPosts, recordId, optFilters...)
+	if err != nil { return nil, err }
+	return Posts_Wrap(_record), nil
+}
+
+func Posts_FindFirstRecordByData(app core.App, key string, value any) (*PostsRecord, error) {
+	_record, err := app.FindFirstRecordByData(CollectionPosts, key, value)
+	if err != nil { return nil, err }
+	return Posts_Wrap(_record), nil
+}
+
+func Posts_FindRecordsByFilter(app core.App,
+	filter string,
+	sort string,
+	limit int,
+	offset int,
+	params ...dbx.Params) ([]*PostsRecord, error) {
+	_records, err := app.FindRecordsByFilter(CollectionPosts, filter, sort, limit, offset, params...)
+	if err != nil { return nil, err }
+	records := make([]*PostsRecord, len(_records))
+	for i, _record := range _records { records[i] = Posts_Wrap(_record) }
+	return records, err
+}
+	
+func Posts_New(app core.App) (*PostsRecord, error) {
+	c, err := app.FindCollectionByNameOrId(CollectionPosts)
+	if err != nil { return nil, err }
+	return Posts_Wrap(core.NewRecord(c)), nil
+}
+
+// Posts_FindBySlug returns the record matching the unique index idx_slug
+func Posts_FindBySlug(app core.App, slug string) (*PostsRecord, error) {
+	_record, err := app.FindFirstRecordByFilter(CollectionPosts, "slug = {:p0}", dbx.Params{"p0": slug})
+	if err != nil { return nil, err }
+	return Posts_Wrap(_record), nil
+}
+
+// Posts_FindAllByAuthorAndStatus returns all records matching the index idx_author_status
+func Posts_FindAllByAuthorAndStatus(app core.App, author string, status string) ([]*PostsRecord, error) {
+	return Posts_FindRecordsByFilter(app, "author = {:p0} && status = {:p1}", "", 0, 0, dbx.Params{"p0": author, "p1": status})
+}
+
+// PostsRepository bundles the data access to PostsRecord, services can depend on it instead of core.App
+type PostsRepository interface {
+	Find(id string) (*PostsRecord, error)
+	List(opts ListOptions) (ListResult[*PostsRecord], error)
+	Count(filter Filter) (int64, error)
+	Exists(id string) (bool, error)
+	Save(record *PostsRecord) error
+	Delete(record *PostsRecord) error
+}
+
+type postsRepo struct {
+	app core.App
+}
+
+var _ PostsRepository = (*postsRepo)(nil)
+
+func NewPostsRepo(app core.App) PostsRepository {
+	return &postsRepo{app: app}
+}
+
+func (r *postsRepo) Find(id string) (*PostsRecord, error) {
+	return Posts_FindRecordById(r.app, id)
+}
+
+func (r *postsRepo) List(opts ListOptions) (ListResult[*PostsRecord], error) {
+	return Posts_List(r.app, opts)
+}
+
+func (r *postsRepo) Count(filter Filter) (int64, error) {
+	expr, params := filter.Build()
+	return countRecordsByFilter(r.app, CollectionPosts, expr, params)
+}
+
+func (r *postsRepo) Exists(id string) (bool, error) {
+	total, err := countRecordsByFilter(r.app, CollectionPosts, "id = {:id}", dbx.Params{"id": id})
+	return total > 0, err
+}
+
+func (r *postsRepo) Save(record *PostsRecord) error {
+	return record.Save(r.app)
+}
+
+func (r *postsRepo) Delete(record *PostsRecord) error {
+	return record.Delete(r.app)
+}
+
+// FakePostsRepo is an in-memory PostsRepository for unit tests of services which should run without a database.
+// Filters and sort orders are evaluated in go, relations are not resolved.
+type FakePostsRepo struct {
+	store *fakeStore
+}
+
+var _ PostsRepository = (*FakePostsRepo)(nil)
+
+// NewFakePostsRepo creates a FakePostsRepo containing records, records without id get a random one
+func NewFakePostsRepo(records ...*PostsRecord) (*FakePostsRepo, error) {
+	r := &FakePostsRepo{store: newFakeStore(newFakePostsCollection())}
+	for _, record := range records {
+		if err := r.store.save(record.ProxyRecord()); err != nil { return nil, err }
+	}
+	return r, nil
+}
+
+func (r *FakePostsRepo) Find(id string) (*PostsRecord, error) {
+	record, err := r.store.find(id)
+	if err != nil { return nil, err }
+	return Posts_Wrap(record), nil
+}
+
+func (r *FakePostsRepo) List(opts ListOptions) (ListResult[*PostsRecord], error) {
+	_records, page, perPage, total := r.store.list(opts)
+	records := make([]*PostsRecord, len(_records))
+	for i, _record := range _records { records[i] = Posts_Wrap(_record) }
+	return newListResult(records, page, perPage, total), nil
+}
+
+func (r *FakePostsRepo) Count(filter Filter) (int64, error) {
+	return int64(len(r.store.filter(filter))), nil
+}
+
+func (r *FakePostsRepo) Exists(id string) (bool, error) {
+	_, ok := r.store.records[id]
+	return ok, nil
+}
+
+func newFakePostsCollection() *core.Collection {
+	collection := core.NewCollection("base", CollectionPosts)
+	collection.Fields.Add(
+		&core.TextField{Name: "title"},
+		&core.TextField{Name: "slug"},
+		&core.EditorField{Name: "body"},
+		&core.NumberField{Name: "views"},
+		&core.NumberField{Name: "rating"},
+		&core.BoolField{Name: "published"},
+		&core.SelectField{Name: "status", Values: []string{"draft", "published", "archived"}, MaxSelect: 1},
+		&core.SelectField{Name: "tags", Values: []string{"go", "web", "db"}, MaxSelect: 3},
+		&core.RelationField{Name: "author", MaxSelect: 1},
+		&core.RelationField{Name: "reviewers", MaxSelect: 5},
+		&core.FileField{Name: "images", MaxSelect: 5},
+		&core.JSONField{Name: "meta"},
+		&core.URLField{Name: "website"},
+		&core.EmailField{Name: "contact"},
+		&core.AutodateField{Name: "published_at", OnCreate: true, OnUpdate: false},
+		&core.DateField{Name: "due"},
+		&core.RelationField{Name: "category", MaxSelect: 1},
+		&core.AutodateField{Name: "created", OnCreate: true, OnUpdate: false},
+		&core.AutodateField{Name: "updated", OnCreate: true, OnUpdate: true},
+	)
+	return collection
+}
+
+// New creates an unsaved record of the fake collection, use it instead of Posts_New which needs an app
+func (r *FakePostsRepo) New() *PostsRecord {
+	return Posts_Wrap(core.NewRecord(r.store.collection))
+}
+
+func (r *FakePostsRepo) Save(record *PostsRecord) error {
+	return r.store.save(record.ProxyRecord())
+}
+
+func (r *FakePostsRepo) Delete(record *PostsRecord) error {
+	return r.store.delete(record.ProxyRecord().Id)
+}
+
+// PostsRecordEvent is a core.RecordEvent with the typed record, call e.Next() in handlers to continue the hook chain
+type PostsRecordEvent struct {
+	*core.RecordEvent
+	Record *PostsRecord
+}
+
+// PostsRecordErrorEvent is a core.RecordErrorEvent with the typed record, call e.Next() in handlers to continue the hook chain
+type PostsRecordErrorEvent struct {
+	*core.RecordErrorEvent
+	Record *PostsRecord
+}
+
+// PostsRecordRequestEvent is a core.RecordRequestEvent with the typed record, call e.Next() in handlers to continue the hook chain
+type PostsRecordRequestEvent struct {
+	*core.RecordRequestEvent
+	Record *PostsRecord
+}
+
+// Posts_OnValidate binds handler to app.OnRecordValidate of the posts collection and returns the handler id
+func Posts_OnValidate(app core.App, handler func(e *PostsRecordEvent) error) string {
+	return app.OnRecordValidate(CollectionPosts).BindFunc(func(e *core.RecordEvent) error {
+		return handler(&PostsRecordEvent{RecordEvent: e, Record: Posts_Wrap(e.Record)})
+	})
+}
+
+// Posts_OnCreate binds handler to app.OnRecordCreate of the posts collection and returns the handler id
+func Posts_OnCreate(app core.App, handler func(e *PostsRecordEvent) error) string {
+	return app.OnRecordCreate(CollectionPosts).BindFunc(func(e *core.RecordEvent) error {
+		return handler(&PostsRecordEvent{RecordEvent: e, Record: Posts_Wrap(e.Record)})
+	})
+}
+
+// Posts_OnCreateExecute binds handler to app.OnRecordCreateExecute of the posts collection and returns the handler id
+func Posts_OnCreateExecute(app core.App, handler func(e *PostsRecordEvent) error) string {
+	return app.OnRecordCreateExecute(CollectionPosts).BindFunc(func(e *core.RecordEvent) error {
+		return handler(&PostsRecordEvent{RecordEvent: e, Record: Posts_Wrap(e.Record)})
+	})
+}
+
+// Posts_OnAfterCreateSuccess binds handler to app.OnRecordAfterCreateSuccess of the posts collection and returns the handler id
+func Posts_OnAfterCreateSuccess(app core.App, handler func(e *PostsRecordEvent) error) string {
+	return app.OnRecordAfterCreateSuccess(CollectionPosts).BindFunc(func(e *core.RecordEvent) error {
+		return handler(&PostsRecordEvent{RecordEvent: e, Record: Posts_Wrap(e.Record)})
+	})
+}
+
+// Posts_OnAfterCreateError binds handler to app.OnRecordAfterCreateError of the posts collection and returns the handler id
+func Posts_OnAfterCreateError(app core.App, handler func(e *PostsRecordErrorEvent) error) string {
+	return app.OnRecordAfterCreateError(CollectionPosts).BindFunc(func(e *core.RecordErrorEvent) error {
+		return handler(&PostsRecordErrorEvent{RecordErrorEvent: e, Record: Posts_Wrap(e.Record)})
+	})
+}
+
+// Posts_OnUpdate binds handler to app.OnRecordUpdate of the posts collection and returns the handler id
+func Posts_OnUpdate(app core.App, handler func(e *PostsRecordEvent) error) string {
+	return app.OnRecordUpdate(CollectionPosts).BindFunc(func(e *core.RecordEvent) error {
+		return handler(&PostsRecordEvent{RecordEvent: e, Record: Posts_Wrap(e.Record)})
+	})
+}
+
+// Posts_OnUpdateExecute binds handler to app.OnRecordUpdateExecute of the posts collection and returns the handler id
+func Posts_OnUpdateExecute(app core.App, handler func(e *PostsRecordEvent) error) string {
+	return app.OnRecordUpdateExecute(CollectionPosts).BindFunc(func(e *core.RecordEvent) error {
+		return handler(&PostsRecordEvent{RecordEvent: e, Record: Posts_Wrap(e.Record)})
+	})
+}
+
+// Posts_OnAfterUpdateSuccess binds handler to app.OnRecordAfterUpdateSuccess of the posts collection and returns the handler id
+func Posts_OnAfterUpdateSuccess(app core.App, handler func(e *PostsRecordEvent) error) string {
+	return app.OnRecordAfterUpdateSuccess(CollectionPosts).BindFunc(func(e *core.RecordEvent) error {
+		return handler(&PostsRecordEvent{RecordEvent: e, Record: Posts_Wrap(e.Record)})
+	})
+}
+
+// Posts_OnAfterUpdateError binds handler to app.OnRecordAfterUpdateError of the posts collection and returns the handler id
+func Posts_OnAfterUpdateError(app core.App, handler func(e *PostsRecordErrorEvent) error) string {
+	return app.OnRecordAfterUpdateError(CollectionPosts).BindFunc(func(e *core.RecordErrorEvent) error {
+		return handler(&PostsRecordErrorEvent{RecordErrorEvent: e, Record: Posts_Wrap(e.Record)})
+	})
+}
+
+// Posts_OnDelete binds handler to app.OnRecordDelete of the posts collection and returns the handler id
+func Posts_OnDelete(app core.App, handler func(e *PostsRecordEvent) error) string {
+	return app.OnRecordDelete(CollectionPosts).BindFunc(func(e *core.RecordEvent) error {
+		return handler(&PostsRecordEvent{RecordEvent: e, Record: Posts_Wrap(e.Record)})
+	})
+}
+
+// Posts_OnDeleteExecute binds handler to app.OnRecordDeleteExecute of the posts collection and returns the handler id
+func Posts_OnDeleteExecute(app core.App, handler func(e *PostsRecordEvent) error) string {
+	return app.OnRecordDeleteExecute(CollectionPosts).BindFunc(func(e *core.RecordEvent) error {
+		return handler(&PostsRecordEvent{RecordEvent: e, Record: Posts_Wrap(e.Record)})
+	})
+}
+
+// Posts_OnAfterDeleteSuccess binds handler to app.OnRecordAfterDeleteSuccess of the posts collection and returns the handler id
+func Posts_OnAfterDeleteSuccess(app core.App, handler func(e *PostsRecordEvent) error) string {
+	return app.OnRecordAfterDeleteSuccess(CollectionPosts).BindFunc(func(e *core.RecordEvent) error {
+		return handler(&PostsRecordEvent{RecordEvent: e, Record: Posts_Wrap(e.Record)})
+	})
+}
+
+// Posts_OnAfterDeleteError binds handler to app.OnRecordAfterDeleteError of the posts collection and returns the handler id
+func Posts_OnAfterDeleteError(app core.App, handler func(e *PostsRecordErrorEvent) error) string {
+	return app.OnRecordAfterDeleteError(CollectionPosts).BindFunc(func(e *core.RecordErrorEvent) error {
+		return handler(&PostsRecordErrorEvent{RecordErrorEvent: e, Record: Posts_Wrap(e.Record)})
+	})
+}
+
+// Posts_OnViewRequest binds handler to app.OnRecordViewRequest of the posts collection and returns the handler id
+func Posts_OnViewRequest(app core.App, handler func(e *PostsRecordRequestEvent) error) string {
+	return app.OnRecordViewRequest(CollectionPosts).BindFunc(func(e *core.RecordRequestEvent) error {
+		return handler(&PostsRecordRequestEvent{RecordRequestEvent: e, Record: Posts_Wrap(e.Record)})
+	})
+}
+
+// Posts_OnCreateRequest binds handler to app.OnRecordCreateRequest of the posts collection and returns the handler id
+func Posts_OnCreateRequest(app core.App, handler func(e *PostsRecordRequestEvent) error) string {
+	return app.OnRecordCreateRequest(CollectionPosts).BindFunc(func(e *core.RecordRequestEvent) error {
+		return handler(&PostsRecordRequestEvent{RecordRequestEvent: e, Record: Posts_Wrap(e.Record)})
+	})
+}
+
+// Posts_OnUpdateRequest binds handler to app.OnRecordUpdateRequest of the posts collection and returns the handler id
+func Posts_OnUpdateRequest(app core.App, handler func(e *PostsRecordRequestEvent) error) string {
+	return app.OnRecordUpdateRequest(CollectionPosts).BindFunc(func(e *core.RecordRequestEvent) error {
+		return handler(&PostsRecordRequestEvent{RecordRequestEvent: e, Record: Posts_Wrap(e.Record)})
+	})
+}
+
+// Posts_OnDeleteRequest binds handler to app.OnRecordDeleteRequest of the posts collection and returns the handler id
+func Posts_OnDeleteRequest(app core.App, handler func(e *PostsRecordRequestEvent) error) string {
+	return app.OnRecordDeleteRequest(CollectionPosts).BindFunc(func(e *core.RecordRequestEvent) error {
+		return handler(&PostsRecordRequestEvent{RecordRequestEvent: e, Record: Posts_Wrap(e.Record)})
+	})
+}
+
+func Categories_Wrap(record *core.Record) *CategoriesRecord {
+	typedRecord := &CategoriesRecord{}
+	typedRecord.SetProxyRecord(record)
+	return typedRecord
+}
+
+func Categories_FindRecordById(app core.App, recordId string, optFilters ...func(q *dbx.SelectQuery) error) (*CategoriesRecord, error) {
+	_record, err := app.FindRecordById(CollectionCategories, recordId, optFilters...)
+	if err != nil { return nil, err }
+	return Categories_Wrap(_record), nil
+}
+
+func Categories_FindFirstRecordByData(app core.App, key string, value any) (*CategoriesRecord, error) {
+	_record, err := app.FindFirstRecordByData(CollectionCategories, key, value)
+	if err != nil { return nil, err }
+	return Categories_Wrap(_record), nil
+}
+
+func Categories_FindRecordsByFilter(app core.App,
+	filter string,
+	sort string,
+	limit int,
+	offset int,
+	params ...dbx.Params) ([]*CategoriesRecord, error) {
+	_records, err := app.FindRecordsByFilter(CollectionCategories, filter, sort, limit, offset, params...)
+	if err != nil { return nil, err }
+	records := make([]*CategoriesRecord, len(_records))
+	for i, _record := range _records { records[i] = Categories_Wrap(_record) }
+	return records, err
+}
+	
+func Categories_New(app core.App) (*CategoriesRecord, error) {
+	c, err := app.FindCollectionByNameOrId(CollectionCategories)
+	if err != nil { return nil, err }
+	return Categories_Wrap(core.NewRecord(c)), nil
+}
+
+// CategoriesRepository bundles the data access to CategoriesRecord, services can depend on it instead of core.App
+type CategoriesRepository interface {
+	Find(id string) (*CategoriesRecord, error)
+	List(opts ListOptions) (ListResult[*CategoriesRecord], error)
+	Count(filter Filter) (int64, error)
+	Exists(id string) (bool, error)
+	Save(record *CategoriesRecord) error
+	Delete(record *CategoriesRecord) error
+}
+
+type categoriesRepo struct {
+	app core.App
+}
+
+var _ CategoriesRepository = (*categoriesRepo)(nil)
+
+func NewCategoriesRepo(app core.App) CategoriesRepository {
+	return &categoriesRepo{app: app}
+}
+
+func (r *categoriesRepo) Find(id string) (*CategoriesRecord, error) {
+	return Categories_FindRecordById(r.app, id)
+}
+
+func (r *categoriesRepo) List(opts ListOptions) (ListResult[*CategoriesRecord], error) {
+	return Categories_List(r.app, opts)
+}
+
+func (r *categoriesRepo) Count(filter Filter) (int64, error) {
+	expr, params := filter.Build()
+	return countRecordsByFilter(r.app, CollectionCategories, expr, params)
+}
+
+func (r *categoriesRepo) Exists(id string) (bool, error) {
+	total, err := countRecordsByFilter(r.app, CollectionCategories, "id = {:id}", dbx.Params{"id": id})
+	return total > 0, err
+}
+
+func (r *categoriesRepo) Save(record *CategoriesRecord) error {
+	return record.Save(r.app)
+}
+
+func (r *categoriesRepo) Delete(record *CategoriesRecord) error {
+	return record.Delete(r.app)
+}
+
+// FakeCategoriesRepo is an in-memory CategoriesRepository for unit tests of services which should run without a database.
+// Filters and sort orders are evaluated in go, relations are not resolved.
+type FakeCategoriesRepo struct {
+	store *fakeStore
+}
+
+var _ CategoriesRepository = (*FakeCategoriesRepo)(nil)
+
+// NewFakeCategoriesRepo creates a FakeCategoriesRepo containing records, records without id get a random one
+func NewFakeCategoriesRepo(records ...*CategoriesRecord) (*FakeCategoriesRepo, error) {
+	r := &FakeCategoriesRepo{store: newFakeStore(newFakeCategoriesCollection())}
+	for _, record := range records {
+		if err := r.store.save(record.ProxyRecord()); err != nil { return nil, err }
+	}
+	return r, nil
+}
+
+func (r *FakeCategoriesRepo) Find(id string) (*CategoriesRecord, error) {
+	record, err := r.store.find(id)
+	if err != nil { return nil, err }
+	return Categories_Wrap(record), nil
+}
+
+func (r *FakeCategoriesRepo) List(opts ListOptions) (ListResult[*CategoriesRecord], error) {
+	_records, page, perPage, total := r.store.list(opts)
+	records := make([]*CategoriesRecord, len(_records))
+	for i, _record := range _records { records[i] = Categories_Wrap(_record) }
+	return newListResult(records, page, perPage, total), nil
+}
+
+func (r *FakeCategoriesRepo) Count(filter Filter) (int64, error) {
+	return int64(len(r.store.filter(filter))), nil
+}
+
+func (r *FakeCategoriesRepo) Exists(id string) (bool, error) {
+	_, ok := r.store.records[id]
+	return ok, nil
+}
+
+func newFakeCategoriesCollection() *core.Collection {
+	collection := core.NewCollection("base", CollectionCategories)
+	collection.Fields.Add(
+		&core.TextField{Name: "name"},
+		&core.RelationField{Name: "parent", MaxSelect: 1},
+		&core.RelationField{Name: "featured", MaxSelect: 1},
+	)
+	return collection
+}
+
+// New creates an unsaved record of the fake collection, use it instead of Categories_New which needs an app
+func (r *FakeCategoriesRepo) New() *CategoriesRecord {
+	return Categories_Wrap(core.NewRecord(r.store.collection))
+}
+
+func (r *FakeCategoriesRepo) Save(record *CategoriesRecord) error {
+	return r.store.save(record.ProxyRecord())
+}
+
+func (r *FakeCategoriesRepo) Delete(record *CategoriesRecord) error {
+	return r.store.delete(record.ProxyRecord().Id)
+}
+
+// CategoriesRecordEvent is a core.RecordEvent with the typed record, call e.Next() in handlers to continue the hook chain
+type CategoriesRecordEvent struct {
+	*core.RecordEvent
+	Record *CategoriesRecord
+}
+
+// CategoriesRecordErrorEvent is a core.RecordErrorEvent with the typed record, call e.Next() in handlers to continue the hook chain
+type CategoriesRecordErrorEvent struct {
+	*core.RecordErrorEvent
+	Record *CategoriesRecord
+}
+
+// CategoriesRecordRequestEvent is a core.RecordRequestEvent with the typed record, call e.Next() in handlers to continue the hook chain
+type CategoriesRecordRequestEvent struct {
+	*core.RecordRequestEvent
+	Record *CategoriesRecord
+}
+
+// Categories_OnValidate binds handler to app.OnRecordValidate of the categories collection and returns the handler id
+func Categories_OnValidate(app core.App, handler func(e *CategoriesRecordEvent) error) string {
+	return app.OnRecordValidate(CollectionCategories).BindFunc(func(e *core.RecordEvent) error {
+		return handler(&CategoriesRecordEvent{RecordEvent: e, Record: Categories_Wrap(e.Record)})
+	})
+}
+
+// Categories_OnCreate binds handler to app.OnRecordCreate of the categories collection and returns the handler id
+func Categories_OnCreate(app core.App, handler func(e *CategoriesRecordEvent) error) string {
+	return app.OnRecordCreate(CollectionCategories).BindFunc(func(e *core.RecordEvent) error {
+		return handler(&CategoriesRecordEvent{RecordEvent: e, Record: Categories_Wrap(e.Record)})
+	})
+}
+
+// Categories_OnCreateExecute binds handler to app.OnRecordCreateExecute of the categories collection and returns the handler id
+func Categories_OnCreateExecute(app core.App, handler func(e *CategoriesRecordEvent) error) string {
+	return app.OnRecordCreateExecute(CollectionCategories).BindFunc(func(e *core.RecordEvent) error {
+		return handler(&CategoriesRecordEvent{RecordEvent: e, Record: Categories_Wrap(e.Record)})
+	})
+}
+
+// Categories_OnAfterCreateSuccess binds handler to app.OnRecordAfterCreateSuccess of the categories collection and returns the handler id
+func Categories_OnAfterCreateSuccess(app core.App, handler func(e *CategoriesRecordEvent) error) string {
+	return app.OnRecordAfterCreateSuccess(CollectionCategories).BindFunc(func(e *core.RecordEvent) error {
+		return handler(&CategoriesRecordEvent{RecordEvent: e, Record: Categories_Wrap(e.Record)})
+	})
+}
+
+// Categories_OnAfterCreateError binds handler to app.OnRecordAfterCreateError of the categories collection and returns the handler id
+func Categories_OnAfterCreateError(app core.App, handler func(e *CategoriesRecordErrorEvent) error) string {
+	return app.OnRecordAfterCreateError(CollectionCategories).BindFunc(func(e *core.RecordErrorEvent) error {
+		return handler(&CategoriesRecordErrorEvent{RecordErrorEvent: e, Record: Categories_Wrap(e.Record)})
+	})
+}
+
+// Categories_OnUpdate binds handler to app.OnRecordUpdate of the categories collection and returns the handler id
+func Categories_OnUpdate(app core.App, handler func(e *CategoriesRecordEvent) error) string {
+	return app.OnRecordUpdate(CollectionCategories).BindFunc(func(e *core.RecordEvent) error {
+		return handler(&CategoriesRecordEvent{RecordEvent: e, Record: Categories_Wrap(e.Record)})
+	})
+}
+
+// Categories_OnUpdateExecute binds handler to app.OnRecordUpdateExecute of the categories collection and returns the handler id
+func Categories_OnUpdateExecute(app core.App, handler func(e *CategoriesRecordEvent) error) string {
+	return app.OnRecordUpdateExecute(CollectionCategories).BindFunc(func(e *core.RecordEvent) error {
+		return handler(&CategoriesRecordEvent{RecordEvent: e, Record: Categories_Wrap(e.Record)})
+	})
+}
+
+// Categories_OnAfterUpdateSuccess binds handler to app.OnRecordAfterUpdateSuccess of the categories collection and returns the handler id
+func Categories_OnAfterUpdateSuccess(app core.App, handler func(e *CategoriesRecordEvent) error) string {
+	return app.OnRecordAfterUpdateSuccess(CollectionCategories).BindFunc(func(e *core.RecordEvent) error {
+		return handler(&CategoriesRecordEvent{RecordEvent: e, Record: Categories_Wrap(e.Record)})
+	})
+}
+
+// Categories_OnAfterUpdateError binds handler to app.OnRecordAfterUpdateError of the categories collection and returns the handler id
+func Categories_OnAfterUpdateError(app core.App, handler func(e *CategoriesRecordErrorEvent) error) string {
+	return app.OnRecordAfterUpdateError(CollectionCategories).BindFunc(func(e *core.RecordErrorEvent) error {
+		return handler(&CategoriesRecordErrorEvent{RecordErrorEvent: e, Record: Categories_Wrap(e.Record)})
+	})
+}
+
+// Categories_OnDelete binds handler to app.OnRecordDelete of the categories collection and returns the handler id
+func Categories_OnDelete(app core.App, handler func(e *CategoriesRecordEvent) error) string {
+	return app.OnRecordDelete(CollectionCategories).BindFunc(func(e *core.RecordEvent) error {
+		return handler(&CategoriesRecordEvent{RecordEvent: e, Record: Categories_Wrap(e.Record)})
+	})
+}
+
+// Categories_OnDeleteExecute binds handler to app.OnRecordDeleteExecute of the categories collection and returns the handler id
+func Categories_OnDeleteExecute(app core.App, handler func(e *CategoriesRecordEvent) error) string {
+	return app.OnRecordDeleteExecute(CollectionCategories).BindFunc(func(e *core.RecordEvent) error {
+		return handler(&CategoriesRecordEvent{RecordEvent: e, Record: Categories_Wrap(e.Record)})
+	})
+}
+
+// Categories_OnAfterDeleteSuccess binds handler to app.OnRecordAfterDeleteSuccess of the categories collection and returns the handler id
+func Categories_OnAfterDeleteSuccess(app core.App, handler func(e *CategoriesRecordEvent) error) string {
+	return app.OnRecordAfterDeleteSuccess(CollectionCategories).BindFunc(func(e *core.RecordEvent) error {
+		return handler(&CategoriesRecordEvent{RecordEvent: e, Record: Categories_Wrap(e.Record)})
+	})
+}
+
+// Categories_OnAfterDeleteError binds handler to app.OnRecordAfterDeleteError of the categories collection and returns the handler id
+func Categories_OnAfterDeleteError(app core.App, handler func(e *CategoriesRecordErrorEvent) error) string {
+	return app.OnRecordAfterDeleteError(CollectionCategories).BindFunc(func(e *core.RecordErrorEvent) error {
+		return handler(&CategoriesRecordErrorEvent{RecordErrorEvent: e, Record: Categories_Wrap(e.Record)})
+	})
+}
+
+// Categories_OnViewRequest binds handler to app.OnRecordViewRequest of the categories collection and returns the handler id
+func Categories_OnViewRequest(app core.App, handler func(e *CategoriesRecordRequestEvent) error) string {
+	return app.OnRecordViewRequest(CollectionCategories).BindFunc(func(e *core.RecordRequestEvent) error {
+		return handler(&CategoriesRecordRequestEvent{RecordRequestEvent: e, Record: Categories_Wrap(e.Record)})
+	})
+}
+
+// Categories_OnCreateRequest binds handler to app.OnRecordCreateRequest of the categories collection and returns the handler id
+func Categories_OnCreateRequest(app core.App, handler func(e *CategoriesRecordRequestEvent) error) string {
+	return app.OnRecordCreateRequest(CollectionCategories).BindFunc(func(e *core.RecordRequestEvent) error {
+		return handler(&CategoriesRecordRequestEvent{RecordRequestEvent: e, Record: Categories_Wrap(e.Record)})
+	})
+}
+
+// Categories_OnUpdateRequest binds handler to app.OnRecordUpdateRequest of the categories collection and returns the handler id
+func Categories_OnUpdateRequest(app core.App, handler func(e *CategoriesRecordRequestEvent) error) string {
+	return app.OnRecordUpdateRequest(CollectionCategories).BindFunc(func(e *core.RecordRequestEvent) error {
+		return handler(&CategoriesRecordRequestEvent{RecordRequestEvent: e, Record: Categories_Wrap(e.Record)})
+	})
+}
+
+// Categories_OnDeleteRequest binds handler to app.OnRecordDeleteRequest of the categories collection and returns the handler id
+func Categories_OnDeleteRequest(app core.App, handler func(e *CategoriesRecordRequestEvent) error) string {
+	return app.OnRecordDeleteRequest(CollectionCategories).BindFunc(func(e *core.RecordRequestEvent) error {
+		return handler(&CategoriesRecordRequestEvent{RecordRequestEvent: e, Record: Categories_Wrap(e.Record)})
+	})
+}
+
+func PostStats_Wrap(record *core.Record) *PostStatsRecord {
+	typedRecord := &PostStatsRecord{}
+	typedRecord.SetProxyRecord(record)
+	return typedRecord
+}
+
+func PostStats_FindRecordById(app core.App, recordId string, optFilters ...func(q *dbx.SelectQuery) error) (*PostStatsRecord, error) {
+	_record, err := app.FindRecordById(CollectionPostStats, recordId, optFilters...)
+	if err != nil { return nil, err }
+	return PostStats_Wrap(_record), nil
+}
+
+func PostStats_FindFirstRecordByData(app core.App, key string, value any) (*PostStatsRecord, error) {
+	_record, err := app.FindFirstRecordByData(CollectionPostStats, key, value)
+	if err != nil { return nil, err }
+	return PostStats_Wrap(_record), nil
+}
+
+func PostStats_FindRecordsByFilter(app core.App,
+	filter string,
+	sort string,
+	limit int,
+	offset int,
+	params ...dbx.Params) ([]*PostStatsRecord, error) {
+	_records, err := app.FindRecordsByFilter(CollectionPostStats, filter, sort, limit, offset, params...)
+	if err != nil { return nil, err }
+	records := make([]*PostStatsRecord, len(_records))
+	for i, _record := range _records { records[i] = PostStats_Wrap(_record) }
+	return records, err
+}
+
+// PostStatsRepository bundles the data access to PostStatsRecord, services can depend on it instead of core.App
+type PostStatsRepository interface {
+	Find(id string) (*PostStatsRecord, error)
+	List(opts ListOptions) (ListResult[*PostStatsRecord], error)
+	Count(filter Filter) (int64, error)
+	Exists(id string) (bool, error)
+}
+
+type postStatsRepo struct {
+	app core.App
+}
+
+var _ PostStatsRepository = (*postStatsRepo)(nil)
+
+func NewPostStatsRepo(app core.App) PostStatsRepository {
+	return &postStatsRepo{app: app}
+}
+
+func (r *postStatsRepo) Find(id string) (*PostStatsRecord, error) {
+	return PostStats_FindRecordById(r.app, id)
+}
+
+func (r *postStatsRepo) List(opts ListOptions) (ListResult[*PostStatsRecord], error) {
+	return PostStats_List(r.app, opts)
+}
+
+func (r *postStatsRepo) Count(filter Filter) (int64, error) {
+	expr, params := filter.Build()
+	return countRecordsByFilter(r.app, CollectionPostStats, expr, params)
+}
+
+func (r *postStatsRepo) Exists(id string) (bool, error) {
+	total, err := countRecordsByFilter(r.app, CollectionPostStats, "id = {:id}", dbx.Params{"id": id})
+	return total > 0, err
+}
+
+// FakePostStatsRepo is an in-memory PostStatsRepository for unit tests of services which should run without a database.
+// Filters and sort orders are evaluated in go, relations are not resolved.
+type FakePostStatsRepo struct {
+	store *fakeStore
+}
+
+var _ PostStatsRepository = (*FakePostStatsRepo)(nil)
+
+// NewFakePostStatsRepo creates a FakePostStatsRepo containing records, records without id get a random one
+func NewFakePostStatsRepo(records ...*PostStatsRecord) (*FakePostStatsRepo, error) {
+	r := &FakePostStatsRepo{store: newFakeStore(newFakePostStatsCollection())}
+	for _, record := range records {
+		if err := r.store.save(record.ProxyRecord()); err != nil { return nil, err }
+	}
+	return r, nil
+}
+
+func (r *FakePostStatsRepo) Find(id string) (*PostStatsRecord, error) {
+	record, err := r.store.find(id)
+	if err != nil { return nil, err }
+	return PostStats_Wrap(record), nil
+}
+
+func (r *FakePostStatsRepo) List(opts ListOptions) (ListResult[*PostStatsRecord], error) {
+	_records, page, perPage, total := r.store.list(opts)
+	records := make([]*PostStatsRecord, len(_records))
+	for i, _record := range _records { records[i] = PostStats_Wrap(_record) }
+	return newListResult(records, page, perPage, total), nil
+}
+
+func (r *FakePostStatsRepo) Count(filter Filter) (int64, error) {
+	return int64(len(r.store.filter(filter))), nil
+}
+
+func (r *FakePostStatsRepo) Exists(id string) (bool, error) {
+	_, ok := r.store.records[id]
+	return ok, nil
+}
+
+func newFakePostStatsCollection() *core.Collection {
+	collection := core.NewCollection("view", CollectionPostStats)
+	collection.Fields.Add(
+		&core.TextField{Name: "title"},
+		&core.NumberField{Name: "views"},
+	)
+	return collection
+}
+
+// PostStatsRecordRequestEvent is a core.RecordRequestEvent with the typed record, call e.Next() in handlers to continue the hook chain
+type PostStatsRecordRequestEvent struct {
+	*core.RecordRequestEvent
+	Record *PostStatsRecord
+}
+
+// PostStats_OnViewRequest binds handler to app.OnRecordViewRequest of the post_stats collection and returns the handler id
+func PostStats_OnViewRequest(app core.App, handler func(e *PostStatsRecordRequestEvent) error) string {
+	return app.OnRecordViewRequest(CollectionPostStats).BindFunc(func(e *core.RecordRequestEvent) error {
+		return handler(&PostStatsRecordRequestEvent{RecordRequestEvent: e, Record: PostStats_Wrap(e.Record)})
+	})
+}
+
+// VerifySchema checks that the collections and fields used by the generated code exist in app with a compatible
+// type and cardinality. Call it on startup to detect code generated from a different schema, all differences are
+// returned joined.
+func VerifySchema(app core.App) error {
+	return errors.Join(
+		verifyCollection(app, CollectionUsers, "auth", []schemaField{
+			{Name: "id", Type: "text", Multiple: false, Target: ""},
+			{Name: "email", Type: "email", Multiple: false, Target: ""},
+			{Name: "emailVisibility", Type: "bool", Multiple: false, Target: ""},
+			{Name: "verified", Type: "bool", Multiple: false, Target: ""},
+			{Name: "name", Type: "text", Multiple: false, Target: ""},
+			{Name: "avatar", Type: "file", Multiple: false, Target: ""},
+			{Name: "created", Type: "autodate", Multiple: false, Target: ""},
+			{Name: "updated", Type: "autodate", Multiple: false, Target: ""},
+		}),
+		verifyCollection(app, CollectionPosts, "base", []schemaField{
+			{Name: "id", Type: "text", Multiple: false, Target: ""},
+			{Name: "title", Type: "text", Multiple: false, Target: ""},
+			{Name: "slug", Type: "text", Multiple: false, Target: ""},
+			{Name: "body", Type: "editor", Multiple: false, Target: ""},
+			{Name: "views", Type: "number", Multiple: false, Target: ""},
+			{Name: "rating", Type: "number", Multiple: false, Target: ""},
+			{Name: "published", Type: "bool", Multiple: false, Target: ""},
+			{Name: "status", Type: "select", Multiple: false, Target: ""},
+			{Name: "tags", Type: "select", Multiple: true, Target: ""},
+			{Name: "author", Type: "relation", Multiple: false, Target: "users"},
+			{Name: "reviewers", Type: "relation", Multiple: true, Target: "users"},
+			{Name: "images", Type: "file", Multiple: true, Target: ""},
+			{Name: "meta", Type: "json", Multiple: false, Target: ""},
+			{Name: "website", Type: "url", Multiple: false, Target: ""},
+			{Name: "contact", Type: "email", Multiple: false, Target: ""},
+			{Name: "published_at", Type: "autodate", Multiple: false, Target: ""},
+			{Name: "due", Type: "date", Multiple: false, Target: ""},
+			{Name: "category", Type: "relation", Multiple: false, Target: "categories"},
+			{Name: "created", Type: "autodate", Multiple: false, Target: ""},
+			{Name: "updated", Type: "autodate", Multiple: false, Target: ""},
+		}),
+		verifyCollection(app, CollectionCategories, "base", []schemaField{
+			{Name: "id", Type: "text", Multiple: false, Target: ""},
+			{Name: "name", Type: "text", Multiple: false, Target: ""},
+			{Name: "parent", Type: "relation", Multiple: false, Target: "categories"},
+			{Name: "featured", Type: "relation", Multiple: false, Target: "posts"},
+		}),
+		verifyCollection(app, CollectionPostStats, "view", []schemaField{
+			{Name: "id", Type: "text", Multiple: false, Target: ""},
+			{Name: "title", Type: "text", Multiple: false, Target: ""},
+			{Name: "views", Type: "number", Multiple: false, Target: ""},
+		}),
+	)
+}
+
+// SchemaJSON contains the collections the code was generated for, as exported by pocketbase
+const SchemaJSON = "[{\"id\":\"_pb_users_auth_\",\"name\":\"users\",\"type\":\"auth\",\"system\":false,\"indexes\":[\"CREATE UNIQUE INDEX `idx_tokenKey__pb_users_auth_` ON `users` (`tokenKey`)\",\"CREATE UNIQUE INDEX `idx_email__pb_users_auth_` ON `users` (`email`) WHERE `email` != ''\"],\"fields\":[{\"id\":\"text3208210256\",\"name\":\"id\",\"type\":\"text\",\"system\":true,\"primaryKey\":true,\"autogeneratePattern\":\"[a-z0-9]{15}\",\"required\":true,\"hidden\":false,\"min\":15,\"max\":15,\"pattern\":\"^[a-z0-9]+$\"},{\"id\":\"password901924565\",\"name\":\"password\",\"type\":\"password\",\"system\":true,\"hidden\":true,\"required\":true,\"min\":8},{\"id\":\"text2504183744\",\"name\":\"tokenKey\",\"type\":\"text\",\"system\":true,\"hidden\":true,\"required\":true,\"min\":30,\"max\":60},{\"id\":\"email3885137012\",\"name\":\"email\",\"type\":\"email\",\"system\":true,\"required\":true,\"hidden\":false,\"exceptDomains\":null,\"onlyDomains\":null},{\"id\":\"bool1547992806\",\"name\":\"emailVisibility\",\"type\":\"bool\",\"system\":true,\"hidden\":false},{\"id\":\"bool256245529\",\"name\":\"verified\",\"type\":\"bool\",\"system\":true,\"hidden\":false},{\"id\":\"text1579384326\",\"name\":\"name\",\"type\":\"text\",\"hidden\":false,\"max\":255},{\"id\":\"file376926767\",\"name\":\"avatar\",\"type\":\"file\",\"hidden\":false,\"maxSelect\":1,\"maxSize\":0,\"mimeTypes\":[\"image/jpeg\",\"image/png\"],\"thumbs\":[\"100x100\"]},{\"id\":\"autodate2990389176\",\"name\":\"created\",\"type\":\"autodate\",\"system\":false,\"hidden\":false,\"onCreate\":true,\"onUpdate\":false},{\"id\":\"autodate3332085495\",\"name\":\"updated\",\"type\":\"autodate\",\"system\":false,\"hidden\":false,\"onCreate\":true,\"onUpdate\":true}],\"passwordAuth\":{\"enabled\":true,\"identityFields\":[\"email\"]}},{\"id\":\"pbc_posts\",\"name\":\"posts\",\"type\":\"base\",\"system\":false,\"indexes\":[\"CREATE UNIQUE INDEX `idx_slug` ON `posts` (`slug`)\",\"CREATE INDEX `idx_author_status` ON `posts` (`author`, `status`)\"],\"fields\":[{\"id\":\"text3208210256\",\"name\":\"id\",\"type\":\"text\",\"system\":true,\"primaryKey\":true,\"autogeneratePattern\":\"[a-z0-9]{15}\",\"required\":true,\"min\":15,\"max\":15,\"pattern\":\"^[a-z0-9]+$\"},{\"id\":\"f1\",\"name\":\"title\",\"type\":\"text\",\"required\":true,\"min\":3,\"max\":100},{\"id\":\"f2\",\"name\":\"slug\",\"type\":\"text\",\"required\":true,\"pattern\":\"^[a-z0-9-]+$\"},{\"id\":\"f3\",\"name\":\"body\",\"type\":\"editor\",\"maxSize\":0},{\"id\":\"f4\",\"name\":\"views\",\"type\":\"number\",\"min\":0,\"max\":null,\"onlyInt\":true},{\"id\":\"f5\",\"name\":\"rating\",\"type\":\"number\",\"required\":true,\"min\":1,\"max\":5},{\"id\":\"f6\",\"name\":\"published\",\"type\":\"bool\"},{\"id\":\"f7\",\"name\":\"status\",\"type\":\"select\",\"maxSelect\":1,\"required\":true,\"values\":[\"draft\",\"published\",\"archived\"]},{\"id\":\"f8\",\"name\":\"tags\",\"type\":\"select\",\"maxSelect\":3,\"values\":[\"go\",\"web\",\"db\"]},{\"id\":\"f9\",\"name\":\"author\",\"type\":\"relation\",\"required\":true,\"collectionId\":\"_pb_users_auth_\",\"maxSelect\":1,\"minSelect\":0},{\"id\":\"f10\",\"name\":\"reviewers\",\"type\":\"relation\",\"collectionId\":\"_pb_users_auth_\",\"maxSelect\":5,\"minSelect\":0},{\"id\":\"f11\",\"name\":\"images\",\"type\":\"file\",\"maxSelect\":5,\"maxSize\":5242880,\"mimeTypes\":[\"image/png\"],\"thumbs\":[\"0x100\",\"50x50\"]},{\"id\":\"f12\",\"name\":\"meta\",\"type\":\"json\",\"maxSize\":0},{\"id\":\"f13\",\"name\":\"website\",\"type\":\"url\",\"onlyDomains\":[\"example.com\"]},{\"id\":\"f14\",\"name\":\"contact\",\"type\":\"email\",\"exceptDomains\":[\"spam.com\"]},{\"id\":\"f15\",\"name\":\"published_at\",\"type\":\"autodate\",\"onCreate\":true,\"onUpdate\":false},{\"id\":\"f16\",\"name\":\"due\",\"type\":\"date\"},{\"id\":\"f17\",\"name\":\"category\",\"type\":\"relation\",\"collectionId\":\"pbc_categories\",\"maxSelect\":1},{\"id\":\"f25\",\"name\":\"created\",\"type\":\"autodate\",\"onCreate\":true,\"onUpdate\":false},{\"id\":\"f26\",\"name\":\"updated\",\"type\":\"autodate\",\"onCreate\":true,\"onUpdate\":true}]},{\"id\":\"pbc_categories\",\"name\":\"categories\",\"type\":\"base\",\"system\":false,\"indexes\":[],\"fields\":[{\"id\":\"text3208210256\",\"name\":\"id\",\"type\":\"text\",\"system\":true,\"primaryKey\":true,\"autogeneratePattern\":\"[a-z0-9]{15}\",\"required\":true},{\"id\":\"c1\",\"name\":\"name\",\"type\":\"text\",\"required\":true},{\"id\":\"c2\",\"name\":\"parent\",\"type\":\"relation\",\"required\":true,\"collectionId\":\"pbc_categories\",\"maxSelect\":1},{\"id\":\"c3\",\"name\":\"featured\",\"type\":\"relation\",\"required\":true,\"collectionId\":\"pbc_posts\",\"maxSelect\":1}]},{\"id\":\"pbc_stats\",\"name\":\"post_stats\",\"type\":\"view\",\"system\":false,\"indexes\":[],\"viewQuery\":\"SELECT id, title, views FROM posts\",\"fields\":[{\"id\":\"text3208210256\",\"name\":\"id\",\"type\":\"text\",\"system\":true,\"primaryKey\":true,\"autogeneratePattern\":\"[a-z0-9]{15}\",\"required\":true},{\"id\":\"s1\",\"name\":\"title\",\"type\":\"text\"},{\"id\":\"s2\",\"name\":\"views\",\"type\":\"number\"}]}]"
+
+// ImportSchema imports the collections of SchemaJSON into app. If deleteMissing is set, all other non-system
+// collections and fields are deleted together with their records.
+func ImportSchema(app core.App, deleteMissing bool) error {
+	return app.ImportCollectionsByMarshaledJSON([]byte(SchemaJSON), deleteMissing)
+}
+
+// Filter is a typed pocketbase filter expression, use the generated XxxFilter variables to create one
+type Filter struct {
+	field    string
+	operator string
+	value    any
+	children []Filter
+}
+
+// And combines the filter with others, all of them have to match
+func (f Filter) And(others ...Filter) Filter {
+	return Filter{operator: "&&", children: append([]Filter{f}, others...)}
+}
+
+// Or combines the filter with others, one of them has to match
+func (f Filter) Or(others ...Filter) Filter {
+	return Filter{operator: "||", children: append([]Filter{f}, others...)}
+}
+
+// IsEmpty reports whether the filter has no conditions (the zero value)
+func (f Filter) IsEmpty() bool {
+	return f.operator == ""
+}
+
+// Build renders the filter to a pocketbase filter string, all values are passed as placeholders in params
+func (f Filter) Build() (string, dbx.Params) {
+	params := dbx.Params{}
+	return f.build(params), params
+}
+
+func (f Filter) build(params dbx.Params) string {
+	if f.IsEmpty() {
+		return ""
+	}
+
+	if f.field == "" {
+		var parts []string
+		for _, child := range f.children {
+			if expr := child.build(params); expr != "" {
+				parts = append(parts, "("+expr+")")
+			}
+		}
+		return strings.Join(parts, " "+f.operator+" ")
+	}
+
+	placeholder := fmt.Sprintf("filter%d", len(params))
+	params[placeholder] = f.value
+	return fmt.Sprintf("%s %s {:%s}", f.field, f.operator, placeholder)
+}
+
+func newFilter(field string, operator string, value any) Filter {
+	return Filter{field: field, operator: operator, value: value}
+}
+
+// Match evaluates the filter in memory against record, it is used by the generated FakeXxxRepo types and does
+// not resolve relations
+func (f Filter) Match(record *core.Record) bool {
+	if f.IsEmpty() {
+		return true
+	}
+
+	if f.field == "" {
+		for _, child := range f.children {
+			if child.Match(record) == (f.operator == "||") {
+				return f.operator == "||"
+			}
+		}
+		return f.operator == "&&"
+	}
+
+	// like in pocketbase, fields without items never match != with :each
+	if name, ok := strings.CutSuffix(f.field, ":each"); ok {
+		items := record.GetStringSlice(name)
+		if f.operator == "?=" { return slices.Contains(items, fmt.Sprint(f.value)) }
+		return len(items) > 0 && !slices.Contains(items, fmt.Sprint(f.value))
+	}
+
+	var compared int
+	switch value := f.value.(type) {
+	case float64:
+		compared = cmp.Compare(record.GetFloat(f.field), value)
+	case bool:
+		if record.GetBool(f.field) != value { compared = 1 }
+	default:
+		switch f.operator {
+		case "~":
+			return matchLike(record.GetString(f.field), fmt.Sprint(value))
+		case "!~":
+			return !matchLike(record.GetString(f.field), fmt.Sprint(value))
+		}
+		compared = strings.Compare(record.GetString(f.field), fmt.Sprint(value))
+	}
+
+	switch f.operator {
+	case "=", "?=":
+		return compared == 0
+	case "!=":
+		return compared != 0
+	case ">":
+		return compared > 0
+	case ">=":
+		return compared >= 0
+	case "<":
+		return compared < 0
+	case "<=":
+		return compared <= 0
+	}
+
+	return false
+}
+
+// matchLike mirrors the pocketbase ~ operator, a pattern without % matches values containing it literally
+func matchLike(value string, pattern string) bool {
+	if !strings.Contains(pattern, "%") {
+		return strings.Contains(strings.ToLower(value), strings.ToLower(pattern))
+	}
+
+	expr := strings.NewReplacer("%", ".*", "_", ".").Replace(regexp.QuoteMeta(pattern))
+	matched, _ := regexp.MatchString("(?is)^"+expr+"$", value)
+	return matched
+}
+
+type StringFilterField struct{ name string }
+
+func (f StringFilterField) Eq(value string) Filter      { return newFilter(f.name, "=", value) }
+func (f StringFilterField) Neq(value string) Filter     { return newFilter(f.name, "!=", value) }
+func (f StringFilterField) Like(value string) Filter    { return newFilter(f.name, "~", value) }
+func (f StringFilterField) NotLike(value string) Filter { return newFilter(f.name, "!~", value) }
+
+type NumberFilterField struct{ name string }
+
+func (f NumberFilterField) Eq(value float64) Filter  { return newFilter(f.name, "=", value) }
+func (f NumberFilterField) Neq(value float64) Filter { return newFilter(f.name, "!=", value) }
+func (f NumberFilterField) Gt(value float64) Filter  { return newFilter(f.name, ">", value) }
+func (f NumberFilterField) Gte(value float64) Filter { return newFilter(f.name, ">=", value) }
+func (f NumberFilterField) Lt(value float64) Filter  { return newFilter(f.name, "<", value) }
+func (f NumberFilterField) Lte(value float64) Filter { return newFilter(f.name, "<=", value) }
+
+type BoolFilterField struct{ name string }
+
+func (f BoolFilterField) Eq(value bool) Filter { return newFilter(f.name, "=", value) }
+
+type DateFilterField struct{ name string }
+
+func (f DateFilterField) Eq(value types.DateTime) Filter  { return newFilter(f.name, "=", value.String()) }
+func (f DateFilterField) Neq(value types.DateTime) Filter { return newFilter(f.name, "!=", value.String()) }
+func (f DateFilterField) Gt(value types.DateTime) Filter  { return newFilter(f.name, ">", value.String()) }
+func (f DateFilterField) Gte(value types.DateTime) Filter { return newFilter(f.name, ">=", value.String()) }
+func (f DateFilterField) Lt(value types.DateTime) Filter  { return newFilter(f.name, "<", value.String()) }
+func (f DateFilterField) Lte(value types.DateTime) Filter { return newFilter(f.name, "<=", value.String()) }
+
+type EnumFilterField[T ~string] struct{ name string }
+
+func (f EnumFilterField[T]) Eq(value T) Filter  { return newFilter(f.name, "=", string(value)) }
+func (f EnumFilterField[T]) Neq(value T) Filter { return newFilter(f.name, "!=", string(value)) }
+
+// multiple value fields are compared per item, Has matches if any item equals the value and HasNot if there are
+// items and none of them does
+type MultiEnumFilterField[T ~string] struct{ name string }
+
+func (f MultiEnumFilterField[T]) Has(value T) Filter    { return newFilter(f.name+":each", "?=", string(value)) }
+func (f MultiEnumFilterField[T]) HasNot(value T) Filter { return newFilter(f.name+":each", "!=", string(value)) }
+
+type RelationFilterField struct{ name string }
+
+func (f RelationFilterField) Eq(id string) Filter  { return newFilter(f.name, "=", id) }
+func (f RelationFilterField) Neq(id string) Filter { return newFilter(f.name, "!=", id) }
+func (f RelationFilterField) Has(id string) Filter { return newFilter(f.name, "?=", id) }
+
+type MultiValueFilterField struct{ name string }
+
+func (f MultiValueFilterField) Has(value string) Filter    { return newFilter(f.name+":each", "?=", value) }
+func (f MultiValueFilterField) HasNot(value string) Filter { return newFilter(f.name+":each", "!=", value) }
+
+// DefaultPerPage is used by the generated Xxx_List functions if ListOptions.PerPage is not set
+const DefaultPerPage = 30
+
+// SortField is a typed sort key, use the generated XxxSort variables to create one
+type SortField struct{ name string }
+
+func (f SortField) Asc() SortOrder  { return SortOrder(f.name) }
+func (f SortField) Desc() SortOrder { return SortOrder("-" + f.name) }
+
+// SortOrder is a single pocketbase sort expression like "-created"
+type SortOrder string
+
+// ListOptions configures the generated Xxx_List functions, Page starts with 1
+type ListOptions struct {
+	Filter  Filter
+	Sort    []SortOrder
+	Page    int
+	PerPage int
+}
+
+func (opts ListOptions) pagination() (int, int) {
+	page, perPage := opts.Page, opts.PerPage
+	if page < 1 { page = 1 }
+	if perPage < 1 { perPage = DefaultPerPage }
+	return page, perPage
+}
+
+func (opts ListOptions) sort() string {
+	parts := make([]string, len(opts.Sort))
+	for i, order := range opts.Sort { parts[i] = string(order) }
+	return strings.Join(parts, ",")
+}
+
+// ListResult is one page of records returned by the generated Xxx_List functions
+type ListResult[T any] struct {
+	Items      []T
+	Page       int
+	PerPage    int
+	TotalItems int
+	TotalPages int
+}
+
+func newListResult[T any](items []T, page int, perPage int, totalItems int64) ListResult[T] {
+	return ListResult[T]{
+		Items:      items,
+		Page:       page,
+		PerPage:    perPage,
+		TotalItems: int(totalItems),
+		TotalPages: int((totalItems + int64(perPage) - 1) / int64(perPage)),
+	}
+}
+
+// countRecordsByFilter counts the records matching filter, resolving relations and multiple value fields the same
+// way app.FindRecordsByFilter does
+func countRecordsByFilter(app core.App, collectionName string, filter string, params dbx.Params) (int64, error) {
+	if filter == "" {
+		return app.CountRecords(collectionName)
+	}
+
+	collection, err := app.FindCachedCollectionByNameOrId(collectionName)
+	if err != nil { return 0, err }
+
+	resolver := core.NewRecordFieldResolver(app, collection, nil, true)
+	expr, err := search.FilterData(filter).BuildExpr(resolver, params)
+	if err != nil { return 0, fmt.Errorf("invalid filter expression: %w", err) }
+
+	query := app.RecordQuery(collection).Select(collection.Name + ".id").AndWhere(expr)
+	resolver.UpdateQuery(query)
+	subQuery := query.Build()
+
+	return app.CountRecords(collection, dbx.NewExp("[[id]] IN ("+subQuery.SQL()+")", subQuery.Params()))
+}
+
+// AllBatchSize is the number of records loaded per query by the generated Xxx_All functions
+var AllBatchSize = 500
+
+// allRecords iterates over all records matching filter ordered by id, loading them in batches of AllBatchSize.
+// Batches continue after the last seen id instead of using an offset, so late pages are as fast as the first one.
+func allRecords(app core.App, collectionName string, filter string, params ...dbx.Params) iter.Seq2[*core.Record, error] {
+	return func(yield func(*core.Record, error) bool) {
+		batchFilter := "id > {:allLastId}"
+		if filter != "" { batchFilter = "(" + filter + ") && " + batchFilter }
+
+		batchParams := dbx.Params{}
+		for _, p := range params {
+			for k, v := range p { batchParams[k] = v }
+		}
+		batchParams["allLastId"] = ""
+
+		for {
+			records, err := app.FindRecordsByFilter(collectionName, batchFilter, "id", AllBatchSize, 0, batchParams)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for _, record := range records {
+				if !yield(record, nil) { return }
+			}
+
+			if len(records) < AllBatchSize { return }
+			batchParams["allLastId"] = records[len(records)-1].Id
+		}
+	}
+}
+
+// fakeStore keeps the records of a generated FakeXxxRepo in memory. Records are cloned on the way in and out, so
+// changes only become visible after Save like with a database.
+type fakeStore struct {
+	collection *core.Collection
+	ids        []string
+	records    map[string]*core.Record
+}
+
+func newFakeStore(collection *core.Collection) *fakeStore {
+	return &fakeStore{collection: collection, records: map[string]*core.Record{}}
+}
+
+func (s *fakeStore) find(id string) (*core.Record, error) {
+	record, ok := s.records[id]
+	if !ok { return nil, sql.ErrNoRows }
+	return record.Clone(), nil
+}
+
+// filter returns clones of all records matching filter in insertion order
+func (s *fakeStore) filter(filter Filter) []*core.Record {
+	var records []*core.Record
+	for _, id := range s.ids {
+		if record := s.records[id]; filter.Match(record) { records = append(records, record.Clone()) }
+	}
+	return records
+}
+
+func (s *fakeStore) list(opts ListOptions) ([]*core.Record, int, int, int64) {
+	page, perPage := opts.pagination()
+	records := s.filter(opts.Filter)
+	sortFakeRecords(records, opts.Sort)
+	start := min((page-1)*perPage, len(records))
+	end := min(start+perPage, len(records))
+	return records[start:end], page, perPage, int64(len(records))
+}
+
+// save stores a clone of record, like the database it assigns a random id and sets the autodate fields
+func (s *fakeStore) save(record *core.Record) error {
+	if record.Id == "" { record.Id = core.GenerateDefaultRandomId() }
+	_, exists := s.records[record.Id]
+	for _, field := range s.collection.Fields {
+		if autodate, ok := field.(*core.AutodateField); ok && (autodate.OnCreate && !exists || autodate.OnUpdate) {
+			record.SetRaw(autodate.Name, types.NowDateTime())
+		}
+	}
+	if err := record.PostScan(); err != nil { return err }
+	if !exists { s.ids = append(s.ids, record.Id) }
+	s.records[record.Id] = record.Clone()
+	return nil
+}
+
+func (s *fakeStore) delete(id string) error {
+	if _, ok := s.records[id]; !ok { return sql.ErrNoRows }
+	delete(s.records, id)
+	s.ids = slices.DeleteFunc(s.ids, func(v string) bool { return v == id })
+	return nil
+}
+
+// sortFakeRecords orders records like the sort expressions of ListOptions, records with equal values keep their order
+func sortFakeRecords(records []*core.Record, orders []SortOrder) {
+	slices.SortStableFunc(records, func(a *core.Record, b *core.Record) int {
+		for _, order := range orders {
+			field, desc := strings.CutPrefix(string(order), "-")
+			field = strings.TrimPrefix(field, "+")
+
+			var compared int
+			switch value := a.Get(field).(type) {
+			case float64:
+				compared = cmp.Compare(value, b.GetFloat(field))
+			default:
+				compared = strings.Compare(a.GetString(field), b.GetString(field))
+			}
+
+			if desc { compared = -compared }
+			if compared != 0 { return compared }
+		}
+		return 0
+	})
+}
+
+// ValidationError is returned by the generated Save, Validate and XxxStruct.Validate methods if the record is invalid
+type ValidationError struct {
+	Collection string
+	// Fields maps the pocketbase field names, which are the values of the XxxFields variables, to their validation
+	// error. The errors of nested validation.Errors, like those of hooks validating json fields, are also added with
+	// their joined path like "meta.title". Errors not related to a single field use their pocketbase key.
+	Fields map[string]error
+
+	errs validation.Errors
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Collection, e.errs.Error())
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.errs
+}
+
+// Field returns the validation error of the field, nil if the field is valid
+func (e *ValidationError) Field(name string) error {
+	return e.Fields[name]
+}
+
+// newRecordError converts validation.Errors returned by pocketbase to a *ValidationError, other errors are
+// returned as is
+func newRecordError(collectionName string, err error) error {
+	var errs validation.Errors
+	if !errors.As(err, &errs) {
+		return err
+	}
+
+	fields := make(map[string]error, len(errs))
+	addFieldErrors(fields, "", errs)
+
+	return &ValidationError{Collection: collectionName, Fields: fields, errs: errs}
+}
+
+// addFieldErrors adds errs to fields with their name prefixed by prefix, nested validation.Errors are added as well
+func addFieldErrors(fields map[string]error, prefix string, errs validation.Errors) {
+	for name, err := range errs {
+		fields[prefix+name] = err
+
+		var nested validation.Errors
+		if errors.As(err, &nested) { addFieldErrors(fields, prefix+name+".", nested) }
+	}
+}
+
+// fileURL builds the url pocketbase serves the file of a record at, thumb is only added if it is not empty
+func fileURL(baseURL string, collectionName string, recordId string, name string, thumb string) string {
+	output := strings.TrimRight(baseURL, "/") + "/api/files/" + url.PathEscape(collectionName) + "/" + url.PathEscape(recordId) + "/" + url.PathEscape(name)
+	if thumb != "" { output += "?thumb=" + url.QueryEscape(thumb) }
+	return output
+}
+
+// AuthRecord is implemented by the records of all auth collections, the methods are inherited from core.Record
+type AuthRecord interface {
+	core.RecordProxy
+	Email() string
+	SetEmail(email string)
+	EmailVisibility() bool
+	SetEmailVisibility(visible bool)
+	Verified() bool
+	SetVerified(verified bool)
+	SetPassword(password string)
+	ValidatePassword(password string) bool
+	TokenKey() string
+	RefreshTokenKey()
+	NewAuthToken() (string, error)
+}
+
+// DefaultTextMax is the maximum length pocketbase uses for text fields without max
+const DefaultTextMax = 5000
+
+// DefaultFileMaxSize is the maximum file size pocketbase uses for file fields without maxSize
+const DefaultFileMaxSize = 5 << 20
+
+// DefaultEditorMaxSize and DefaultJSONMaxSize are the maximum sizes in bytes pocketbase uses for editor and json
+// fields without maxSize
+const (
+	DefaultEditorMaxSize = 5 << 20
+	DefaultJSONMaxSize   = 5 << 20
+)
+
+func deref[T any](value *T) T {
+	if value == nil {
+		var zero T
+		return zero
+	}
+	return *value
+}
+
+func limit(value float64) *float64 {
+	return &value
+}
+
+// nonEmpty returns values as strings without the empty ones, single select and relation fields use an empty string
+// for no value
+func nonEmpty[T ~string](values ...T) []string {
+	output := make([]string, 0, len(values))
+	for _, value := range values {
+		if value != "" { output = append(output, string(value)) }
+	}
+	return output
+}
+
+func validateRequired(required bool, empty bool) error {
+	if required && empty { return validation.ErrRequired }
+	return nil
+}
+
+func validateText(value string, required bool, min int, max int, pattern string) error {
+	if value == "" { return validateRequired(required, true) }
+
+	length := len([]rune(value))
+	if max == 0 { max = DefaultTextMax }
+	if min > 0 && length < min {
+		return validation.NewError("validation_min_text_constraint", "Must be at least {{.min}} character(s)").SetParams(map[string]any{"min": min})
+	}
+	if length > max {
+		return validation.NewError("validation_max_text_constraint", "Must be no more than {{.max}} character(s)").SetParams(map[string]any{"max": max})
+	}
+	if pattern != "" {
+		if match, _ := regexp.MatchString(pattern, value); !match {
+			return validation.NewError("validation_invalid_format", "Invalid value format")
+		}
+	}
+	return nil
+}
+
+func validateEditor(value string, required bool, maxSize int64) error {
+	if value == "" { return validateRequired(required, true) }
+
+	if maxSize <= 0 { maxSize = DefaultEditorMaxSize }
+	if int64(len(value)) > maxSize {
+		return validation.NewError("validation_content_size_limit", "The maximum allowed content size is {{.maxSize}} bytes").SetParams(map[string]any{"maxSize": maxSize})
+	}
+	return nil
+}
+
+// validateJSON checks the size of the json encoding of value, which is what pocketbase stores
+func validateJSON(value any, required bool, empty bool, maxSize int64) error {
+	if empty { return validateRequired(required, true) }
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return validation.NewError("validation_invalid_json", "Must be a valid json value")
+	}
+	if maxSize <= 0 { maxSize = DefaultJSONMaxSize }
+	if int64(len(raw)) > maxSize {
+		return validation.NewError("validation_json_size_limit", "The maximum allowed JSON size is {{.maxSize}} bytes").SetParams(map[string]any{"maxSize": maxSize})
+	}
+	return nil
+}
+
+func validateNumber(value float64, required bool, min *float64, max *float64, onlyInt bool) error {
+	if value == 0 { return validateRequired(required, true) }
+
+	if onlyInt && value != float64(int64(value)) {
+		return validation.NewError("validation_only_int_constraint", "Decimal numbers are not allowed")
+	}
+	if min != nil && value < *min {
+		return validation.NewError("validation_min_number_constraint", fmt.Sprintf("Must be larger than %f", *min))
+	}
+	if max != nil && value > *max {
+		return validation.NewError("validation_max_number_constraint", fmt.Sprintf("Must be less than %f", *max))
+	}
+	return nil
+}
+
+func validateEmail(value string, required bool, onlyDomains []string, exceptDomains []string) error {
+	if value == "" { return validateRequired(required, true) }
+
+	if err := is.EmailFormat.Validate(value); err != nil { return err }
+	domain := value[strings.LastIndex(value, "@")+1:]
+	if len(onlyDomains) > 0 && !slices.Contains(onlyDomains, domain) || slices.Contains(exceptDomains, domain) {
+		return validation.NewError("validation_email_domain_not_allowed", "Email domain is not allowed")
+	}
+	return nil
+}
+
+func validateURL(value string, required bool, onlyDomains []string, exceptDomains []string) error {
+	if value == "" { return validateRequired(required, true) }
+
+	if is.URL.Validate(value) != nil {
+		return validation.NewError("validation_invalid_url", "Must be a valid url")
+	}
+	parsed, _ := url.Parse(value)
+	if len(onlyDomains) > 0 && !slices.Contains(onlyDomains, parsed.Host) || slices.Contains(exceptDomains, parsed.Host) {
+		return validation.NewError("validation_url_domain_not_allowed", "Url domain is not allowed")
+	}
+	return nil
+}
+
+func validateDate(value string, required bool) error {
+	if value == "" { return validateRequired(required, true) }
+
+	if _, err := types.ParseDateTime(value); err != nil {
+		return validation.NewError("validation_invalid_date", "Must be a valid date")
+	}
+	return nil
+}
+
+// validateValues validates select, relation and file fields, allowed is empty if every value is allowed
+func validateValues(values []string, required bool, minSelect int, maxSelect int, allowed []string) error {
+	if len(values) == 0 { return validateRequired(required, true) }
+
+	if minSelect > 0 && len(values) < minSelect {
+		return validation.NewError("validation_not_enough_values", "Select at least {{.minSelect}}").SetParams(map[string]any{"minSelect": minSelect})
+	}
+	if len(values) > max(maxSelect, 1) {
+		return validation.NewError("validation_too_many_values", "Select no more than {{.maxSelect}}").SetParams(map[string]any{"maxSelect": max(maxSelect, 1)})
+	}
+	for _, value := range values {
+		if len(allowed) > 0 && !slices.Contains(allowed, value) {
+			return validation.NewError("validation_invalid_value", "Invalid value {{.value}}").SetParams(map[string]any{"value": value})
+		}
+	}
+	return nil
+}
+
+// validateUpload checks a file before it is uploaded, the mime type is derived from the file extension while
+// pocketbase checks the content
+func validateUpload(name string, size int64, maxSize int64, mimeTypes []string) error {
+	if maxSize <= 0 { maxSize = DefaultFileMaxSize }
+	if size > maxSize {
+		return validation.NewError("validation_file_size_limit", "Failed to upload {{.file}} - the maximum allowed file size is {{.maxSize}} bytes.").SetParams(map[string]any{"file": name, "maxSize": maxSize})
+	}
+
+	mimeType, _, _ := strings.Cut(mime.TypeByExtension(filepath.Ext(name)), ";")
+	if len(mimeTypes) > 0 && !slices.Contains(mimeTypes, mimeType) {
+		return validation.NewError("validation_invalid_mime_type", "{{.file}} mime type must be one of: {{.types}}.").SetParams(map[string]any{"file": name, "types": strings.Join(mimeTypes, ", ")})
+	}
+	return nil
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+// convertStrings converts the values of a multiple select field to its enum type
+func convertStrings[T ~string](values []string) []T {
+	output := make([]T, len(values))
+	for i, value := range values { output[i] = T(value) }
+	return output
+}
+
+// structJSON converts the value of a json field to the map used by the XxxStruct types, it fails if the value is
+// not a json object
+func structJSON(name string, value any) (map[string]any, error) {
+	raw, ok := value.(types.JSONRaw)
+	if !ok {
+		var err error
+		if raw, err = json.Marshal(value); err != nil { return nil, fmt.Errorf("%s: %w", name, err) }
+	}
+
+	var output map[string]any
+	if len(raw) == 0 { return output, nil }
+	if err := json.Unmarshal(raw, &output); err != nil { return nil, fmt.Errorf("%s: %w", name, err) }
+	return output, nil
+}
+
+// jsonChanged compares the values of a json field by their json encoding
+func jsonChanged(original any, value any) bool {
+	originalJSON, _ := json.Marshal(original)
+	valueJSON, _ := json.Marshal(value)
+	return string(originalJSON) != string(valueJSON)
+}
+
+// schemaField is a field the generated code relies on
+type schemaField struct {
+	Name     string
+	Type     string
+	Multiple bool
+	// Target is the collection name of relation fields
+	Target string
+}
+
+// stringFieldTypes are the field types read and written as plain strings, the generated code works with each of them
+var stringFieldTypes = []string{core.FieldTypeText, core.FieldTypeEditor, core.FieldTypeEmail, core.FieldTypeURL}
+
+func compatibleFieldType(expected string, actual string) bool {
+	return expected == actual || slices.Contains(stringFieldTypes, expected) && slices.Contains(stringFieldTypes, actual)
+}
+
+// verifyCollection checks that the collection exists with the type and fields the generated code relies on
+func verifyCollection(app core.App, name string, collectionType string, fields []schemaField) error {
+	collection, err := app.FindCollectionByNameOrId(name)
+	if err != nil { return fmt.Errorf("collection %s: %w", name, err) }
+	if collection.Type != collectionType {
+		return fmt.Errorf("collection %s: type is %s instead of %s", name, collection.Type, collectionType)
+	}
+
+	var errs []error
+	for _, expected := range fields {
+		field := collection.Fields.GetByName(expected.Name)
+		if field == nil {
+			errs = append(errs, fmt.Errorf("field %s.%s: missing", name, expected.Name))
+			continue
+		}
+		if !compatibleFieldType(expected.Type, field.Type()) {
+			errs = append(errs, fmt.Errorf("field %s.%s: type is %s instead of %s", name, expected.Name, field.Type(), expected.Type))
+			continue
+		}
+
+		multiValuer, ok := field.(core.MultiValuer)
+		if multiple := ok && multiValuer.IsMultiple(); multiple != expected.Multiple {
+			errs = append(errs, fmt.Errorf("field %s.%s: multiple is %t instead of %t", name, expected.Name, multiple, expected.Multiple))
+		}
+
+		if relation, ok := field.(*core.RelationField); ok && expected.Target != "" {
+			target, err := app.FindCollectionByNameOrId(relation.CollectionId)
+			if err != nil || target.Name != expected.Target {
+				errs = append(errs, fmt.Errorf("field %s.%s: does not reference %s", name, expected.Name, expected.Target))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,98 @@
+package collections
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+)
+
+// newTestPost returns an unsaved post with an expanded author, the collections are read from schema.json so no
+// database is needed
+func newTestPost(tb testing.TB) *PostsRecord {
+	tb.Helper()
+
+	data, err := os.ReadFile("schema.json")
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	var schema struct {
+		Items []struct {
+			Id     string          `json:"id"`
+			Name   string          `json:"name"`
+			Type   string          `json:"type"`
+			Fields json.RawMessage `json:"fields"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		tb.Fatal(err)
+	}
+
+	collections := map[string]*core.Collection{}
+	for _, item := range schema.Items {
+		collection := core.NewCollection(item.Type, item.Name, item.Id)
+		if err := json.Unmarshal(item.Fields, &collection.Fields); err != nil {
+			tb.Fatal(err)
+		}
+		collections[item.Name] = collection
+	}
+
+	user := Users_Wrap(core.NewRecord(collections[CollectionUsers]))
+	user.ProxyRecord().Id = "user00000000001"
+	user.SetName("Author")
+	user.SetEmail("author@example.com")
+
+	post := Posts_Wrap(core.NewRecord(collections[CollectionPosts]))
+	post.ProxyRecord().Id = "post00000000001"
+	post.SetTitle("Hello world")
+	post.SetSlug("hello-world")
+	post.SetBody("<p>Hello</p>")
+	post.SetViews(42)
+	post.SetRating(4)
+	post.SetStatus(string(PostsStatusOptions_Published))
+	post.SetTags([]string{string(PostsTagsOptions_Go), string(PostsTagsOptions_Web)})
+	post.SetAuthor(user.Id())
+	post.SetReviewers([]string{user.Id()})
+	post.SetMeta(map[string]any{"source": "import", "version": 2})
+	post.SetExpand(map[string]any{"author": user.ProxyRecord(), "reviewers": []*core.Record{user.ProxyRecord()}})
+
+	return post
+}
+
+func TestToStruct(t *testing.T) {
+	post := newTestPost(t)
+
+	s, err := post.ToStruct()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := post.PublicExportStruct(); !reflect.DeepEqual(s, expected) {
+		t.Fatalf("ToStruct() = %#v, expected %#v", s, expected)
+	}
+}
+
+func BenchmarkToStruct(b *testing.B) {
+	post := newTestPost(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := post.ToStruct(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPublicExportStruct(b *testing.B) {
+	post := newTestPost(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		post.PublicExportStruct()
+	}
+}
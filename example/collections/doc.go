@@ -0,0 +1,5 @@
+// Package collections is generated with --embed-schema from the collections in schema.json and shows the generated
+// code. Its tests compare ToStruct with PublicExportStruct:
+//
+//	go test ./example/collections -bench .
+package collections
@@ -17,11 +17,19 @@ type GeneratorFlags struct {
 	AllCollections     bool
 	CollectionsInclude []string
 	CollectionsExclude []string
+	CollectionsRelated bool
 
 	Output string
 
 	// Extra flags
 	MakeNonRequiredOptional bool
+	IdentifierSuffix        string
+	EmbedSchema             bool
+
+	// Migration flags
+	Snapshot      string
+	MigrationsDir string
+	DeleteMissing bool
 }
 
 func GetGenerateGoCommand(fromPocketBase bool, callback func(cmd *cobra.Command, args []string, generatorFlags *GeneratorFlags)) *cobra.Command {
@@ -50,10 +58,49 @@ func GetGenerateGoCommand(fromPocketBase bool, callback func(cmd *cobra.Command,
 	rootCmd.PersistentFlags().BoolVarP(&generatorFlags.DisableForm, "collections-all", "a", false, "Select all collections include system collections")
 	rootCmd.PersistentFlags().StringSliceVarP(&generatorFlags.CollectionsInclude, "collections-include", "i", []string{}, "Collections to include (Overrides default selection or all collections)")
 	rootCmd.PersistentFlags().StringSliceVarP(&generatorFlags.CollectionsExclude, "collections-exclude", "x", []string{}, "Collections to exclude")
+	rootCmd.PersistentFlags().BoolVarP(&generatorFlags.CollectionsRelated, "collections-related", "r", false, "Include collections referenced by relations of selected collections (otherwise these relations become plain ids)")
 
 	rootCmd.PersistentFlags().StringVarP(&generatorFlags.Output, "output", "o", "", "Output file path")
 
 	rootCmd.PersistentFlags().BoolVar(&generatorFlags.MakeNonRequiredOptional, "non-required-optional", false, "Make non required fields optional properties (with question mark)")
+	rootCmd.PersistentFlags().StringVar(&generatorFlags.IdentifierSuffix, "identifier-suffix", "Field", "Suffix appended to generated identifiers which collide with other fields or core.Record methods")
+	rootCmd.PersistentFlags().BoolVar(&generatorFlags.EmbedSchema, "embed-schema", false, "Embed the selected collections json as SchemaJSON and generate ImportSchema")
 
 	return rootCmd
 }
+
+func GetGenerateMigrationCommand(fromPocketBase bool, callback func(cmd *cobra.Command, args []string, generatorFlags *GeneratorFlags)) *cobra.Command {
+	generatorFlags := &GeneratorFlags{}
+
+	migrationCmd := &cobra.Command{
+		Use:   "generate-migration",
+		Short: "Generate a go migration from collection changes",
+		Long:  "Generate a pocketbase go migration for the changes of the selected collections since the schema embedded in the last generated file, select the same collections as for the generated file",
+		Run: func(cmd *cobra.Command, args []string) {
+			callback(cmd, args, generatorFlags)
+		},
+	}
+
+	if !fromPocketBase {
+		migrationCmd.Flags().BoolVarP(&generatorFlags.DisableForm, "disable-form", "d", false, "Disable form")
+		migrationCmd.Flags().BoolVarP(&generatorFlags.DisableLogs, "disable-logs", "l", false, "Disable logs, only return errors")
+
+		migrationCmd.Flags().StringVarP(&generatorFlags.Host, "host-url", "u", "", "Pocketbase host url (e. g. http://127.0.0.1:8090)")
+		migrationCmd.Flags().StringVarP(&generatorFlags.Email, "email", "e", "", "Pocketbase email")
+		migrationCmd.Flags().StringVarP(&generatorFlags.Password, "password", "p", "", "Pocketbase password")
+
+		migrationCmd.Flags().StringVarP(&generatorFlags.EncryptionPassword, "encryption-password", "c", "", "credentials.enc.env password")
+	}
+
+	migrationCmd.Flags().BoolVarP(&generatorFlags.AllCollections, "collections-all", "a", false, "Select all collections include system collections")
+	migrationCmd.Flags().StringSliceVarP(&generatorFlags.CollectionsInclude, "collections-include", "i", []string{}, "Collections to include (Overrides default selection or all collections)")
+	migrationCmd.Flags().StringSliceVarP(&generatorFlags.CollectionsExclude, "collections-exclude", "x", []string{}, "Collections to exclude")
+	migrationCmd.Flags().BoolVarP(&generatorFlags.CollectionsRelated, "collections-related", "r", false, "Include collections referenced by relations of selected collections")
+
+	migrationCmd.Flags().StringVarP(&generatorFlags.Snapshot, "snapshot", "s", "", "Last generated go file, it has to be generated with --embed-schema")
+	migrationCmd.Flags().StringVarP(&generatorFlags.MigrationsDir, "migrations-dir", "m", "migrations", "Directory of the migrations package the migration is written to")
+	migrationCmd.Flags().BoolVar(&generatorFlags.DeleteMissing, "delete-missing", false, "Delete the collections of the snapshot which are not selected anymore, otherwise they are kept")
+	_ = migrationCmd.MarkFlagRequired("snapshot")
+
+	return migrationCmd
+}
@@ -3,46 +3,121 @@ package core
 import (
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/arturh85/pocketbase-go-generator/internal/cmd"
+	"github.com/arturh85/pocketbase-go-generator/internal/generator"
 	"github.com/arturh85/pocketbase-go-generator/internal/interpreter"
 	"github.com/arturh85/pocketbase-go-generator/internal/pocketbase_api"
 	"github.com/rs/zerolog/log"
 )
 
-func ProcessCollections(selectedCollections []*pocketbase_api.Collection, allCollections []pocketbase_api.Collection, generatorFlags *cmd.GeneratorFlags) {
-	interpretedCollections := interpreter.InterpretCollections(selectedCollections, allCollections)
+const (
+	importValidation = "github.com/go-ozzo/ozzo-validation/v4"
+	importIs         = "github.com/go-ozzo/ozzo-validation/v4/is"
+	importDbx        = "github.com/pocketbase/dbx"
+	importCore       = "github.com/pocketbase/pocketbase/core"
+	importFilesystem = "github.com/pocketbase/pocketbase/tools/filesystem"
+	importSearch     = "github.com/pocketbase/pocketbase/tools/search"
+	importTypes      = "github.com/pocketbase/pocketbase/tools/types"
+)
 
-	output := make([]string, len(interpretedCollections)+1)
+// importAliases are the names imports are emitted with if it differs from the package name
+var importAliases = map[string]string{
+	importValidation: "validation",
+}
 
-	for i, collection := range interpretedCollections {
-		output[i] = collection.GetGoStruct(generatorFlags) + "\n" + collection.GetGoRecord(generatorFlags)
+// block is a part of the generated file, imports are the packages used by its code
+type block struct {
+	code    string
+	imports []string
+}
+
+// collectionBlock generates a part of the code of every collection
+type collectionBlock struct {
+	generate func(collection *generator.CollectionWithProperties, generatorFlags *cmd.GeneratorFlags) string
+	imports  []string
+	// fileImports are only used by collections which upload files
+	fileImports []string
+	// writable blocks modify records, they are skipped for views as records of views can not be saved
+	writable bool
+}
+
+func (b collectionBlock) block(collection *generator.CollectionWithProperties, generatorFlags *cmd.GeneratorFlags) block {
+	output := block{code: b.generate(collection, generatorFlags), imports: b.imports}
+	if collection.UsesFileUploads() {
+		output.imports = slices.Concat(output.imports, b.fileImports)
 	}
 
-	collectionDefinitions := make([]string, len(interpretedCollections))
-	for i, collection := range interpretedCollections {
-		collectionDefinitions[i] = collection.GetGoCollectionEntry(generatorFlags)
+	return output
+}
+
+// collectionBlocks generates the blocks of collection, writable blocks are left out for views
+func collectionBlocks(collection *generator.CollectionWithProperties, blocks []collectionBlock, generatorFlags *cmd.GeneratorFlags) []block {
+	var output []block
+
+	for _, b := range blocks {
+		if b.writable && collection.IsView() {
+			continue
+		}
+
+		output = append(output, b.block(collection, generatorFlags))
 	}
 
-	output[len(interpretedCollections)] = fmt.Sprintf("const (\n%s\n)", strings.Join(collectionDefinitions, "\n"))
+	return output
+}
 
-	imports := `
-import (
-	"encoding/json"
-	"github.com/pocketbase/dbx"
-	"github.com/pocketbase/pocketbase/core"
-	"github.com/pocketbase/pocketbase/tools/types"
-)
-`
+// recordBlocks are emitted for each collection in this order, followed by the collection name constants
+var recordBlocks = []collectionBlock{
+	{generate: (*generator.CollectionWithProperties).GetGoStruct},
+	{generate: (*generator.CollectionWithProperties).GetGoValidation, imports: []string{importValidation}, writable: true},
+	{generate: (*generator.CollectionWithProperties).GetGoInputTypes, imports: []string{importTypes}, fileImports: []string{importFilesystem}, writable: true},
+	{generate: (*generator.CollectionWithProperties).GetGoFilter, imports: []string{importCore}},
+	{generate: (*generator.CollectionWithProperties).GetGoSort, imports: []string{importCore}},
+	{generate: (*generator.CollectionWithProperties).GetGoAll, imports: []string{"iter", importCore, importDbx}},
+	{generate: (*generator.CollectionWithProperties).GetGoRecord, imports: []string{"encoding/json", importCore, importDbx, importTypes}},
+	{generate: (*generator.CollectionWithProperties).GetGoRecordSetters, imports: []string{importTypes}, writable: true},
+	{generate: (*generator.CollectionWithProperties).GetGoRecordFileUploads, fileImports: []string{importFilesystem}, writable: true},
+	{generate: (*generator.CollectionWithProperties).GetGoRecordChangedFields, imports: []string{"slices", importTypes}, writable: true},
+	{generate: (*generator.CollectionWithProperties).GetGoConversions, imports: []string{importTypes}},
+	{generate: (*generator.CollectionWithProperties).GetGoFromStruct, imports: []string{importCore, importTypes}, writable: true},
+	{generate: (*generator.CollectionWithProperties).GetGoPersistence, imports: []string{importCore}, writable: true},
+}
+
+// helperBlocks are emitted for each collection after the collection name constants
+var helperBlocks = []collectionBlock{
+	{generate: (*generator.CollectionWithProperties).GetGoCollectionHelperFuncs, imports: []string{importCore, importDbx}},
+	{generate: (*generator.CollectionWithProperties).GetGoCollectionConstructor, imports: []string{importCore}, writable: true},
+	{generate: (*generator.CollectionWithProperties).GetGoIndexFinders, imports: []string{importCore, importDbx}},
+	{generate: (*generator.CollectionWithProperties).GetGoRepository, imports: []string{importCore, importDbx}},
+	{generate: (*generator.CollectionWithProperties).GetGoRepositoryWriters, writable: true},
+	{generate: (*generator.CollectionWithProperties).GetGoFakeRepository, imports: []string{importCore}},
+	{generate: (*generator.CollectionWithProperties).GetGoFakeRepositoryWriters, imports: []string{importCore}, writable: true},
+	{generate: (*generator.CollectionWithProperties).GetGoHooks, imports: []string{importCore}},
+	{generate: (*generator.CollectionWithProperties).GetGoWriteHooks, imports: []string{importCore}, writable: true},
+	{generate: (*generator.CollectionWithProperties).GetGoAuthHelpers, imports: []string{"fmt", importCore}},
+}
 
-	joinedData := "package collections\n\n" + imports + "\n" + strings.Join(output, "\n\n")
+// runtimeBlocks contain the helpers used by the generated code of all collections, they are emitted once at the end
+var runtimeBlocks = []block{
+	{code: generator.GetGoFilterRuntime(), imports: []string{"cmp", "fmt", "regexp", "slices", "strings", importCore, importDbx, importTypes}},
+	{code: generator.GetGoListRuntime(), imports: []string{"fmt", "iter", "strings", importCore, importDbx, importSearch}},
+	{code: generator.GetGoFakeRuntime(), imports: []string{"cmp", "database/sql", "slices", "strings", importCore, importTypes}},
+	{code: generator.GetGoPersistenceRuntime(), imports: []string{"errors", "fmt", importValidation}},
+	{code: generator.GetGoFilesRuntime(), imports: []string{"net/url", "strings"}},
+	{code: generator.GetGoAuthRuntime(), imports: []string{importCore}},
+	{code: generator.GetGoValidationRuntime(), imports: []string{"encoding/json", "fmt", "mime", "net/url", "path/filepath", "regexp", "slices", "strings", importValidation, importIs, importTypes}},
+	{code: generator.GetGoConvertRuntime(), imports: []string{"encoding/json", "fmt", importTypes}},
+	{code: generator.GetGoChangesRuntime(), imports: []string{"encoding/json"}},
+	{code: generator.GetGoSchemaRuntime(), imports: []string{"errors", "fmt", "slices", importCore}},
+}
 
-	helper_funcs := make([]string, len(interpretedCollections))
-	for i, collection := range interpretedCollections {
-		helper_funcs[i] = collection.GetGoCollectionHelperFuncs(generatorFlags)
+func ProcessCollections(selectedCollections []*pocketbase_api.Collection, allCollections []pocketbase_api.Collection, generatorFlags *cmd.GeneratorFlags) {
+	joinedData, err := GenerateCollections(selectedCollections, allCollections, generatorFlags)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Could not generate collections")
 	}
-	joinedData += strings.Join(helper_funcs, "\n\n")
 
 	if generatorFlags.Output == "" {
 		fmt.Println(joinedData)
@@ -55,3 +130,93 @@ import (
 
 	}
 }
+
+// GenerateCollections returns the generated go file for the selected collections
+func GenerateCollections(selectedCollections []*pocketbase_api.Collection, allCollections []pocketbase_api.Collection, generatorFlags *cmd.GeneratorFlags) (string, error) {
+	blocks, err := generateBlocks(selectedCollections, allCollections, generatorFlags)
+	if err != nil {
+		return "", err
+	}
+
+	var output strings.Builder
+	var imports []string
+
+	for _, block := range blocks {
+		code := strings.TrimSpace(block.code)
+		if code == "" {
+			continue
+		}
+
+		output.WriteString("\n")
+		output.WriteString(code)
+		output.WriteString("\n")
+
+		imports = append(imports, block.imports...)
+	}
+
+	return "package collections\n\n" + getGoImports(imports) + output.String(), nil
+}
+
+// generateBlocks returns all parts of the generated file in order
+func generateBlocks(selectedCollections []*pocketbase_api.Collection, allCollections []pocketbase_api.Collection, generatorFlags *cmd.GeneratorFlags) ([]block, error) {
+	if generatorFlags.CollectionsRelated {
+		selectedCollections = interpreter.AddRelatedCollections(selectedCollections, allCollections)
+	}
+
+	interpretedCollections := interpreter.InterpretCollections(selectedCollections, allCollections, generatorFlags)
+
+	var blocks []block
+
+	for _, collection := range interpretedCollections {
+		blocks = append(blocks, collectionBlocks(collection, recordBlocks, generatorFlags)...)
+	}
+
+	collectionDefinitions := make([]string, len(interpretedCollections))
+	for i, collection := range interpretedCollections {
+		collectionDefinitions[i] = collection.GetGoCollectionEntry(generatorFlags)
+	}
+
+	blocks = append(blocks, block{code: fmt.Sprintf("const (\n%s\n)", strings.Join(collectionDefinitions, "\n"))})
+
+	for _, collection := range interpretedCollections {
+		blocks = append(blocks, collectionBlocks(collection, helperBlocks, generatorFlags)...)
+	}
+
+	blocks = append(blocks, block{code: generator.GetGoVerifySchema(interpretedCollections, generatorFlags), imports: []string{"errors", importCore}})
+
+	if generatorFlags.EmbedSchema {
+		schemaSnapshot, err := generator.GetGoSchemaSnapshot(selectedCollections, generatorFlags)
+		if err != nil {
+			return nil, fmt.Errorf("could not embed schema: %w", err)
+		}
+
+		blocks = append(blocks, block{code: schemaSnapshot, imports: []string{importCore}})
+	}
+
+	return append(blocks, runtimeBlocks...), nil
+}
+
+// getGoImports returns the import declaration of imports, standard library packages come first
+func getGoImports(imports []string) string {
+	slices.Sort(imports)
+	imports = slices.Compact(imports)
+
+	var standard, external []string
+	for _, path := range imports {
+		line := fmt.Sprintf("\t%q", path)
+		if alias, ok := importAliases[path]; ok {
+			line = fmt.Sprintf("\t%s %q", alias, path)
+		}
+
+		if strings.Contains(strings.Split(path, "/")[0], ".") {
+			external = append(external, line)
+		} else {
+			standard = append(standard, line)
+		}
+	}
+
+	groups := []string{strings.Join(standard, "\n"), strings.Join(external, "\n")}
+	groups = slices.DeleteFunc(groups, func(group string) bool { return group == "" })
+
+	return fmt.Sprintf("import (\n%s\n)\n", strings.Join(groups, "\n\n"))
+}
@@ -0,0 +1,274 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/arturh85/pocketbase-go-generator/internal/cmd"
+	"github.com/arturh85/pocketbase-go-generator/internal/forms"
+	"github.com/arturh85/pocketbase-go-generator/internal/migration"
+	"github.com/arturh85/pocketbase-go-generator/internal/pocketbase_api"
+)
+
+// testSchemas are generated by the tests, collisions contains field names colliding with each other, with
+// generated methods and with go keywords, the generated code of blog is run by TestGeneratedCodeRuns
+var testSchemas = []string{"minimal", "view", "auth", "collisions", "blog"}
+
+func loadTestCollections(t *testing.T, name string) ([]*pocketbase_api.Collection, []pocketbase_api.Collection) {
+	t.Helper()
+
+	data, err := os.ReadFile("testdata/" + name + ".json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := &pocketbase_api.CollectionsResponse{}
+	if err := json.Unmarshal(data, response); err != nil {
+		t.Fatal(err)
+	}
+
+	selected := make([]*pocketbase_api.Collection, len(response.Items))
+	for i := range response.Items {
+		selected[i] = &response.Items[i]
+	}
+
+	return selected, response.Items
+}
+
+// usedPackages returns the names of the packages referenced by code, packages are identifiers which are not
+// declared in code and used as x in x.Name
+func usedPackages(t *testing.T, code string) []string {
+	t.Helper()
+
+	file, err := parser.ParseFile(token.NewFileSet(), "block.go", "package collections\n"+code, 0)
+	if err != nil {
+		t.Fatalf("block does not parse: %v\n%s", err, code)
+	}
+
+	var output []string
+	ast.Inspect(file, func(node ast.Node) bool {
+		selector, ok := node.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		ident, ok := selector.X.(*ast.Ident)
+		if ok && slices.Contains(file.Unresolved, ident) && !slices.Contains(output, ident.Name) {
+			output = append(output, ident.Name)
+		}
+
+		return true
+	})
+
+	return output
+}
+
+func packageName(importPath string) string {
+	if alias, ok := importAliases[importPath]; ok {
+		return alias
+	}
+
+	return path.Base(importPath)
+}
+
+func TestBlockImports(t *testing.T) {
+	for _, schema := range testSchemas {
+		selected, all := loadTestCollections(t, schema)
+
+		blocks, err := generateBlocks(selected, all, &cmd.GeneratorFlags{EmbedSchema: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// other identifiers used as x in x.Name are variables declared by other blocks
+		var packageNames []string
+		for _, block := range blocks {
+			for _, importPath := range block.imports {
+				packageNames = append(packageNames, packageName(importPath))
+			}
+		}
+
+		for _, block := range blocks {
+			declared := make([]string, len(block.imports))
+			for i, importPath := range block.imports {
+				declared[i] = packageName(importPath)
+			}
+
+			for _, used := range usedPackages(t, block.code) {
+				if slices.Contains(packageNames, used) && !slices.Contains(declared, used) {
+					t.Errorf("%s: block uses %s without declaring its import:\n%s", schema, used, block.code)
+				}
+			}
+		}
+	}
+}
+
+func TestViewBlocks(t *testing.T) {
+	selected, all := loadTestCollections(t, "view")
+
+	source, err := GenerateCollections(selected, all, &cmd.GeneratorFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// orders is a base collection and order_totals a view of it
+	tests := []struct {
+		declaration string
+		writable    bool
+	}{
+		{"func (a *%sRecord) Amount() float64", false},
+		{"func (a *%sRecord) ReceiptURL(", false},
+		{"func %s_OnViewRequest(", false},
+		{"func (r *Fake%sRepo) Find(", false},
+		{"func (a *%sRecord) SetAmount(", true},
+		{"func (a *%sRecord) SetReceiptFromPath(", true},
+		{"func (a *%sRecord) AmountChanged() bool", true},
+		{"func (a *%sRecord) Save(", true},
+		{"func %s_New(", true},
+		{"func %s_FromStruct(", true},
+		{"func %s_OnCreate(", true},
+		{"func (r *Fake%sRepo) Save(", true},
+		{"type %sCreate struct", true},
+	}
+
+	for _, test := range tests {
+		if !strings.Contains(source, fmt.Sprintf(test.declaration, "Orders")) {
+			t.Errorf("expected %q for the base collection", fmt.Sprintf(test.declaration, "Orders"))
+		}
+
+		if strings.Contains(source, fmt.Sprintf(test.declaration, "OrderTotals")) == test.writable {
+			t.Errorf("expected %q for the view to be generated %t", fmt.Sprintf(test.declaration, "OrderTotals"), !test.writable)
+		}
+	}
+}
+
+// writeGeneratedPackage writes the generated code of schema to a new package directory and returns its path
+func writeGeneratedPackage(t *testing.T, schema string) string {
+	t.Helper()
+
+	selected, all := loadTestCollections(t, schema)
+
+	source, err := GenerateCollections(selected, all, &cmd.GeneratorFlags{EmbedSchema: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the package has to be inside the module to resolve the pocketbase dependencies, directories starting with _
+	// are ignored by ./...
+	dir, err := os.MkdirTemp(".", "_generated")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	if err := os.WriteFile(filepath.Join(dir, "collections.go"), []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
+
+// TestGeneratedCodeCompiles vets the generated code of every test schema, which fails for unused imports, name
+// collisions and struct tags used twice
+func TestGeneratedCodeCompiles(t *testing.T) {
+	for _, schema := range testSchemas {
+		t.Run(schema, func(t *testing.T) {
+			dir := writeGeneratedPackage(t, schema)
+
+			output, err := exec.Command("go", "vet", "./"+dir).CombinedOutput()
+			if err != nil {
+				t.Fatalf("generated code does not compile: %v\n%s", err, output)
+			}
+		})
+	}
+}
+
+// TestGeneratedCodeRuns runs the tests in testdata/blog against the code generated for the blog schema, they use
+// a pocketbase test app with the embedded schema
+func TestGeneratedCodeRuns(t *testing.T) {
+	if testing.Short() {
+		t.Skip("the generated tests build pocketbase")
+	}
+
+	dir := writeGeneratedPackage(t, "blog")
+
+	tests, err := filepath.Glob("testdata/blog/*_test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range tests {
+		data, err := os.ReadFile(test)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, filepath.Base(test)), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	output, err := exec.Command("go", "test", "./"+dir).CombinedOutput()
+	if err != nil {
+		t.Fatalf("generated code does not pass the tests: %v\n%s", err, output)
+	}
+}
+
+// TestSchemaSnapshotSelection checks that only the selected collections are embedded, generate-migration diffs the
+// same selection against them
+func TestSchemaSnapshotSelection(t *testing.T) {
+	_, all := loadTestCollections(t, "collisions")
+
+	tests := []struct {
+		include  []string
+		related  bool
+		expected []string
+	}{
+		{[]string{"posts"}, false, []string{"posts"}},
+		{[]string{"posts"}, true, []string{"posts", "users", "categories"}},
+		{[]string{"audit", "post_stats"}, false, []string{"post_stats", "audit"}},
+	}
+
+	for _, test := range tests {
+		generatorFlags := &cmd.GeneratorFlags{EmbedSchema: true, CollectionsInclude: test.include, CollectionsRelated: test.related}
+
+		source, err := GenerateCollections(forms.GetSelectedCollections(generatorFlags, all), all, generatorFlags)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		path := filepath.Join(t.TempDir(), "collections.go")
+		if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		snapshot, err := migration.ReadSnapshot(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var collections []pocketbase_api.Collection
+		if err := json.Unmarshal(snapshot, &collections); err != nil {
+			t.Fatal(err)
+		}
+
+		names := make([]string, len(collections))
+		for i, collection := range collections {
+			names[i] = collection.Name
+		}
+
+		if !slices.Equal(names, test.expected) {
+			t.Errorf("%v (related %t): expected snapshot of %v, got %v", test.include, test.related, test.expected, names)
+		}
+	}
+}
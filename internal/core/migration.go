@@ -0,0 +1,69 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/arturh85/pocketbase-go-generator/internal/cmd"
+	"github.com/arturh85/pocketbase-go-generator/internal/interpreter"
+	"github.com/arturh85/pocketbase-go-generator/internal/migration"
+	"github.com/arturh85/pocketbase-go-generator/internal/pocketbase_api"
+	"github.com/rs/zerolog/log"
+)
+
+// ProcessMigration writes a migration for the changes between the schema embedded in generatorFlags.Snapshot and
+// the selected collections to generatorFlags.MigrationsDir. Like the embedded schema, the migration only covers the
+// selected collections, collections of the snapshot which are not selected are only deleted with
+// generatorFlags.DeleteMissing.
+func ProcessMigration(selectedCollections []*pocketbase_api.Collection, allCollections []pocketbase_api.Collection, generatorFlags *cmd.GeneratorFlags) {
+	if generatorFlags.CollectionsRelated {
+		selectedCollections = interpreter.AddRelatedCollections(selectedCollections, allCollections)
+	}
+
+	snapshot, err := migration.ReadSnapshot(generatorFlags.Snapshot)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Could not read schema snapshot")
+	}
+
+	collections := make([]pocketbase_api.Collection, len(selectedCollections))
+	for i, collection := range selectedCollections {
+		collections[i] = *collection
+	}
+
+	missing, err := migration.MissingCollections(snapshot, collections)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Could not read schema snapshot")
+	}
+
+	if len(missing) > 0 && generatorFlags.DeleteMissing {
+		log.Warn().Msgf("Collections %s are not selected, the migration deletes them with all records", strings.Join(missing, ", "))
+	} else if len(missing) > 0 {
+		log.Warn().Msgf("Collections %s are not selected and kept, pass --delete-missing to delete them", strings.Join(missing, ", "))
+	}
+
+	source, err := migration.Generate(filepath.Base(generatorFlags.MigrationsDir), snapshot, collections, generatorFlags.DeleteMissing)
+	if errors.Is(err, migration.ErrNoChanges) {
+		log.Info().Msgf("No collection changes since %s was generated", generatorFlags.Snapshot)
+		return
+	}
+	if err != nil {
+		log.Fatal().Err(err).Msg("Could not generate migration")
+	}
+
+	err = os.MkdirAll(generatorFlags.MigrationsDir, 0755)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Could not create migrations directory")
+	}
+
+	output := filepath.Join(generatorFlags.MigrationsDir, fmt.Sprintf("%d_collections_diff.go", time.Now().Unix()))
+	err = os.WriteFile(output, []byte(source), 0644)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Could not output migration")
+	}
+
+	log.Info().Msgf("Saved migration to %s", output)
+}
@@ -0,0 +1,43 @@
+package collections
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestAll iterates over more records than fit into a batch, the records are paged by id
+func TestAll(t *testing.T) {
+	app := newTestApp(t)
+	author := newTestUser(t, app, "author@example.com")
+	for i := range 7 {
+		newTestPost(t, app, author, fmt.Sprintf("post-%d", i), nil)
+	}
+	newTestPost(t, app, author, "other", nil)
+
+	batchSize := AllBatchSize
+	AllBatchSize = 2
+	t.Cleanup(func() { AllBatchSize = batchSize })
+
+	seen := map[string]bool{}
+	for post, err := range Posts_All(app, "title ~ {:title}", map[string]any{"title": "post-"}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seen[post.Id()] {
+			t.Fatalf("%s returned twice", post.Id())
+		}
+		seen[post.Id()] = true
+	}
+
+	if len(seen) != 7 {
+		t.Errorf("expected 7 posts, got %d", len(seen))
+	}
+
+	count := 0
+	for range Posts_All(app, "") {
+		count++
+		if count == 3 {
+			break
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package collections
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+// newTestApp returns a pocketbase test app containing the collections of SchemaJSON
+func newTestApp(t *testing.T) *tests.TestApp {
+	t.Helper()
+
+	app, err := tests.NewTestApp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(app.Cleanup)
+
+	if err := ImportSchema(app, false); err != nil {
+		t.Fatal(err)
+	}
+
+	return app
+}
+
+func newTestUser(t *testing.T, app core.App, email string) *UsersRecord {
+	t.Helper()
+
+	user, err := Users_New(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user.SetEmail(email)
+	user.SetPassword("1234567890")
+
+	if err := user.Save(app); err != nil {
+		t.Fatal(err)
+	}
+
+	return user
+}
+
+// newTestPost saves a draft post of author, edit is called before saving
+func newTestPost(t *testing.T, app core.App, author *UsersRecord, title string, edit func(post *PostsRecord)) *PostsRecord {
+	t.Helper()
+
+	post, err := Posts_New(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	post.SetTitle(title)
+	post.SetSlug(title)
+	post.SetRating(3)
+	post.SetStatus("draft")
+	post.SetAuthor(author.Id())
+	if edit != nil {
+		edit(post)
+	}
+
+	if err := post.Save(app); err != nil {
+		t.Fatal(err)
+	}
+
+	return post
+}
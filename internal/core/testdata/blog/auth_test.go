@@ -0,0 +1,30 @@
+package collections
+
+import "testing"
+
+func TestAuth(t *testing.T) {
+	app := newTestApp(t)
+	user := newTestUser(t, app, "user@example.com")
+
+	found, err := Users_FindAuthRecordByEmail(app, "user@example.com")
+	if err != nil || found.Id() != user.Id() {
+		t.Fatalf("expected %s by email, got %v %v", user.Id(), found, err)
+	}
+	if !found.ValidatePassword("1234567890") {
+		t.Error("password is not valid")
+	}
+
+	token, err := found.NewAuthToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byToken, err := Users_FindAuthRecordByToken(app, token)
+	if err != nil || byToken.Id() != user.Id() {
+		t.Errorf("expected %s by token, got %v %v", user.Id(), byToken, err)
+	}
+
+	if _, err := Users_FindAuthRecordByToken(app, "invalid"); err == nil {
+		t.Error("invalid token is accepted")
+	}
+}
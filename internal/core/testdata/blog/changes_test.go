@@ -0,0 +1,41 @@
+package collections
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestChangedFields(t *testing.T) {
+	app := newTestApp(t)
+	user := newTestUser(t, app, "user@example.com")
+	saved := newTestPost(t, app, user, "hello", nil)
+
+	post, err := Posts_FindRecordById(app, saved.Id())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fields := post.ChangedFields(); len(fields) != 0 {
+		t.Errorf("expected no changes of a loaded record, got %v", fields)
+	}
+
+	post.SetStatus("published")
+	post.SetMeta(map[string]any{"key": 1})
+	post.SetTags([]string{"go"})
+	post.SetTitle("hello")
+
+	if !post.StatusChanged() || post.OriginalStatus() != "draft" || post.TitleChanged() {
+		t.Errorf("unexpected changes of status %t %s and title %t", post.StatusChanged(), post.OriginalStatus(), post.TitleChanged())
+	}
+	if fields := post.ChangedFields(); !slices.Equal(fields, []string{"status", "tags", "meta"}) {
+		t.Errorf("expected changes of status, tags and meta, got %v", fields)
+	}
+
+	user, err = Users_FindRecordById(app, user.Id())
+	if err != nil {
+		t.Fatal(err)
+	}
+	user.SetEmail("new@example.com")
+	if !user.EmailChanged() || user.OriginalEmail() != "user@example.com" {
+		t.Errorf("unexpected change of email %t %s", user.EmailChanged(), user.OriginalEmail())
+	}
+}
@@ -0,0 +1,58 @@
+package collections
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestToStruct(t *testing.T) {
+	app := newTestApp(t)
+	user := newTestUser(t, app, "user@example.com")
+	post := newTestPost(t, app, user, "hello", func(post *PostsRecord) {
+		post.SetReviewers([]string{user.Id()})
+		post.SetMeta(map[string]any{"key": 1.0})
+	})
+
+	if errs := app.ExpandRecord(post.ProxyRecord(), []string{"author", "reviewers"}, nil); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+
+	s, err := post.ToStruct()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exported := post.PublicExportStruct(); !reflect.DeepEqual(s, exported) {
+		t.Errorf("ToStruct and PublicExportStruct differ:\n%#v\n%#v", s, exported)
+	}
+	if s.Expand.Author.Id != user.Id() || len(*s.Expand.Reviewers) != 1 || (*s.Meta)["key"] != 1.0 {
+		t.Errorf("unexpected struct %#v", s)
+	}
+
+	// the email of auth records is only exported if it is visible
+	if s, _ := user.ToStruct(); s.Email != "" {
+		t.Errorf("expected a hidden email, got %s", s.Email)
+	}
+	user.SetEmailVisibility(true)
+	if s, _ := user.ToStruct(); s.Email != "user@example.com" {
+		t.Errorf("expected a visible email, got %s", s.Email)
+	}
+
+	created, err := Posts_FromStruct(app, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roundTrip, err := created.ToStruct()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Expand = PostsExpanded{}
+	if !reflect.DeepEqual(s, roundTrip) {
+		t.Errorf("FromStruct and ToStruct differ:\n%#v\n%#v", s, roundTrip)
+	}
+
+	post.Set(PostsFields.Meta, []int{1})
+	if _, err := post.ToStruct(); err == nil || !strings.HasPrefix(err.Error(), "meta:") {
+		t.Errorf("expected an error for a json array, got %v", err)
+	}
+}
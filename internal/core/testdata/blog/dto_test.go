@@ -0,0 +1,39 @@
+package collections
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestInputTypes(t *testing.T) {
+	app := newTestApp(t)
+
+	user, err := Users_New(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	UsersCreate{Email: "user@example.com", Password: "1234567890"}.ApplyTo(user)
+	if err := user.Save(app); err != nil {
+		t.Fatal(err)
+	}
+	if !user.ValidatePassword("1234567890") {
+		t.Error("password is not set")
+	}
+
+	views := 5.0
+	post, err := Posts_New(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	PostsCreate{Title: "create", Slug: "create", Rating: 3, Status: "draft", Author: user.Id(), Views: &views, Tags: []PostsTagsOptions{"go"}}.ApplyTo(post)
+	if err := post.Save(app); err != nil {
+		t.Fatal(err)
+	}
+
+	// only the fields which are not nil are updated
+	title := "update"
+	PostsUpdate{Title: &title}.ApplyTo(post)
+	if post.Title() != "update" || post.Views() != 5 || post.Slug() != "create" || !slices.Equal(post.Tags(), []string{"go"}) {
+		t.Errorf("unexpected post after update %s %f %s %v", post.Title(), post.Views(), post.Slug(), post.Tags())
+	}
+}
@@ -0,0 +1,41 @@
+package collections
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeRepository(t *testing.T) {
+	repo, err := NewFakePostsRepo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testRepository(t, repo, func(title string) *PostsRecord {
+		post := repo.New()
+		post.SetTitle(title)
+		return post
+	})
+}
+
+func TestFakeRepositoryAutodate(t *testing.T) {
+	repo, err := NewFakePostsRepo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	post := repo.New()
+	if err := repo.Save(post); err != nil {
+		t.Fatal(err)
+	}
+
+	created, updated := post.Created(), post.Updated()
+	time.Sleep(2 * time.Millisecond)
+	if err := repo.Save(post); err != nil {
+		t.Fatal(err)
+	}
+
+	if post.Created() != created || post.Updated() == updated {
+		t.Errorf("expected only updated to change, created %s -> %s, updated %s -> %s", created, post.Created(), updated, post.Updated())
+	}
+}
@@ -0,0 +1,72 @@
+package collections
+
+import (
+	"strings"
+	"testing"
+)
+
+// testPNG is a 1x1 png image
+var testPNG = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01\x00\x00\x00\x01\x08\x06\x00\x00\x00\x1f\x15\xc4\x89\x00\x00\x00\rIDATx\x9cc\xf8\xff\xff?\x00\x05\xfe\x02\xfe\xa7\x35\x81\x84\x00\x00\x00\x00IEND\xaeB`\x82")
+
+func TestSingleFile(t *testing.T) {
+	app := newTestApp(t)
+	user := newTestUser(t, app, "user@example.com")
+
+	if err := user.SetAvatarFromBytes("me.png", testPNG); err != nil {
+		t.Fatal(err)
+	}
+	if err := user.Save(app); err != nil {
+		t.Fatal(err)
+	}
+
+	url := user.AvatarURL("http://localhost/", UsersAvatarThumb100x100)
+	if !strings.HasPrefix(url, "http://localhost/api/files/users/"+user.Id()+"/me") || !strings.HasSuffix(url, "?thumb=100x100") {
+		t.Errorf("unexpected url %s", url)
+	}
+
+	user.DeleteAvatar(user.Avatar())
+	if err := user.Save(app); err != nil {
+		t.Fatal(err)
+	}
+	if url := user.AvatarURL("http://localhost", ""); url != "" {
+		t.Errorf("expected no url for a deleted file, got %s", url)
+	}
+}
+
+func TestMultipleFiles(t *testing.T) {
+	app := newTestApp(t)
+	post := newTestPost(t, app, newTestUser(t, app, "author@example.com"), "files", nil)
+
+	tests := []struct {
+		name     string
+		edit     func() error
+		prefixes []string
+	}{
+		{"set", func() error { return post.SetImagesFromBytes("a.png", testPNG) }, []string{"a"}},
+		{"add", func() error { return post.AddImagesFromBytes("b.png", testPNG) }, []string{"a", "b"}},
+		{"set replaces", func() error { return post.SetImagesFromBytes("c.png", testPNG) }, []string{"c"}},
+	}
+
+	for _, test := range tests {
+		if err := test.edit(); err != nil {
+			t.Fatal(err)
+		}
+		if err := post.Save(app); err != nil {
+			t.Fatal(err)
+		}
+
+		images := post.Images()
+		if len(images) != len(test.prefixes) {
+			t.Fatalf("%s: expected %d images, got %v", test.name, len(test.prefixes), images)
+		}
+		for i, prefix := range test.prefixes {
+			if !strings.HasPrefix(images[i], prefix) {
+				t.Errorf("%s: expected image %d to start with %s, got %v", test.name, i, prefix, images)
+			}
+		}
+	}
+
+	if urls := post.ImagesURL("http://localhost", ""); len(urls) != 1 {
+		t.Errorf("expected one url, got %v", urls)
+	}
+}
@@ -0,0 +1,117 @@
+package collections
+
+import (
+	"testing"
+
+	"github.com/pocketbase/dbx"
+)
+
+func TestFilterBuild(t *testing.T) {
+	tests := []struct {
+		filter Filter
+		expr   string
+		params dbx.Params
+	}{
+		{Filter{}, "", dbx.Params{}},
+		{PostsFilter.Title.Eq("a"), "title = {:filter0}", dbx.Params{"filter0": "a"}},
+		{PostsFilter.Title.NotLike("a"), "title !~ {:filter0}", dbx.Params{"filter0": "a"}},
+		{PostsFilter.Tags.Has(PostsTagsOptions_Go), "tags:each ?= {:filter0}", dbx.Params{"filter0": "go"}},
+		{PostsFilter.Reviewers.HasNot("x"), "reviewers:each != {:filter0}", dbx.Params{"filter0": "x"}},
+		{PostsFilter.Author.Has("x"), "author ?= {:filter0}", dbx.Params{"filter0": "x"}},
+		{
+			PostsFilter.Title.Like("a").And(PostsFilter.Views.Gt(1).Or(PostsFilter.Published.Eq(true)), Filter{}),
+			"(title ~ {:filter0}) && ((views > {:filter1}) || (published = {:filter2}))",
+			dbx.Params{"filter0": "a", "filter1": 1.0, "filter2": true},
+		},
+	}
+
+	for _, test := range tests {
+		expr, params := test.filter.Build()
+		if expr != test.expr || len(params) != len(test.params) {
+			t.Errorf("expected %q %v, got %q %v", test.expr, test.params, expr, params)
+			continue
+		}
+
+		for name, value := range test.params {
+			if params[name] != value {
+				t.Errorf("%s: expected %s = %v, got %v", expr, name, value, params[name])
+			}
+		}
+	}
+}
+
+// TestFilterMatch compares the records matched in memory with the records pocketbase finds for the same filter
+func TestFilterMatch(t *testing.T) {
+	app := newTestApp(t)
+	alice := newTestUser(t, app, "alice@example.com")
+	bob := newTestUser(t, app, "bob@example.com")
+
+	fake, err := NewFakePostsRepo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	posts := []func(post *PostsRecord){
+		func(post *PostsRecord) {
+			post.SetViews(10)
+			post.SetTags([]string{"go", "web"})
+			post.SetReviewers([]string{alice.Id()})
+		},
+		func(post *PostsRecord) {
+			post.SetViews(20)
+			post.SetPublished(true)
+			post.SetTags([]string{"db"})
+			post.SetReviewers([]string{alice.Id(), bob.Id()})
+		},
+		func(post *PostsRecord) {
+			post.SetStatus("published")
+			post.SetAuthor(bob.Id())
+		},
+	}
+
+	for i, edit := range posts {
+		post := newTestPost(t, app, alice, []string{"alpha", "beta", "gamma"}[i], edit)
+		if err := fake.Save(post); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		count  int64
+	}{
+		{"empty", Filter{}, 3},
+		{"eq", PostsFilter.Title.Eq("beta"), 1},
+		{"neq", PostsFilter.Title.Neq("beta"), 2},
+		{"like", PostsFilter.Title.Like("ET"), 1},
+		{"like with wildcard", PostsFilter.Title.Like("%a"), 3},
+		{"like without wildcard is literal", PostsFilter.Title.Like("a_p"), 0},
+		{"not like", PostsFilter.Title.NotLike("amm"), 2},
+		{"gt", PostsFilter.Views.Gt(10), 1},
+		{"lte", PostsFilter.Views.Lte(10), 2},
+		{"bool", PostsFilter.Published.Eq(false), 2},
+		{"enum", PostsFilter.Status.Eq(PostsStatusOptions_Published), 1},
+		{"multi enum has", PostsFilter.Tags.Has(PostsTagsOptions_Web), 1},
+		// records without items do not match HasNot
+		{"multi enum has not", PostsFilter.Tags.HasNot(PostsTagsOptions_Web), 1},
+		{"relation", PostsFilter.Author.Eq(bob.Id()), 1},
+		{"multi relation has", PostsFilter.Reviewers.Has(bob.Id()), 1},
+		{"multi relation has not", PostsFilter.Reviewers.HasNot(bob.Id()), 1},
+		{"and", PostsFilter.Views.Gte(10).And(PostsFilter.Tags.Has(PostsTagsOptions_Db)), 1},
+		{"or", PostsFilter.Title.Eq("alpha").Or(PostsFilter.Views.Gt(10), PostsFilter.Author.Eq(bob.Id())), 3},
+	}
+
+	repo := NewPostsRepo(app)
+	for _, test := range tests {
+		count, err := repo.Count(test.filter)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+
+		fakeCount, _ := fake.Count(test.filter)
+		if count != test.count || fakeCount != test.count {
+			t.Errorf("%s: expected %d records, pocketbase found %d and Match %d", test.name, test.count, count, fakeCount)
+		}
+	}
+}
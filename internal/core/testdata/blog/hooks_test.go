@@ -0,0 +1,33 @@
+package collections
+
+import "testing"
+
+func TestHooks(t *testing.T) {
+	app := newTestApp(t)
+
+	var created []string
+	Posts_OnCreate(app, func(e *PostsRecordEvent) error {
+		e.Record.SetSlug("hooked-" + e.Record.Title())
+		return e.Next()
+	})
+	Posts_OnAfterCreateSuccess(app, func(e *PostsRecordEvent) error {
+		created = append(created, e.Record.Title())
+		return e.Next()
+	})
+	// hooks of other collections are not called for posts
+	Categories_OnCreate(app, func(e *CategoriesRecordEvent) error {
+		t.Errorf("categories hook called for %s", e.Record.ProxyRecord().Collection().Name)
+		return e.Next()
+	})
+
+	post := newTestPost(t, app, newTestUser(t, app, "author@example.com"), "hello", nil)
+
+	if len(created) != 1 || created[0] != "hello" {
+		t.Errorf("expected one created post, got %v", created)
+	}
+
+	found, err := Posts_FindRecordById(app, post.Id())
+	if err != nil || found.Slug() != "hooked-hello" {
+		t.Errorf("expected the slug set by the hook, got %v %v", found, err)
+	}
+}
@@ -0,0 +1,25 @@
+package collections
+
+import "testing"
+
+func TestIndexFinders(t *testing.T) {
+	app := newTestApp(t)
+	author := newTestUser(t, app, "author@example.com")
+	post := newTestPost(t, app, author, "hello", nil)
+	newTestPost(t, app, author, "published", func(post *PostsRecord) { post.SetStatus("published") })
+
+	found, err := Posts_FindBySlug(app, "hello")
+	if err != nil || found.Id() != post.Id() {
+		t.Errorf("expected %s by unique index, got %v %v", post.Id(), found, err)
+	}
+
+	drafts, err := Posts_FindAllByAuthorAndStatus(app, author.Id(), "draft")
+	if err != nil || len(drafts) != 1 || drafts[0].Id() != post.Id() {
+		t.Errorf("expected only %s by index, got %v %v", post.Id(), drafts, err)
+	}
+
+	user, err := Users_FindByEmail(app, "author@example.com")
+	if err != nil || user.Id() != author.Id() {
+		t.Errorf("expected %s by partial unique index, got %v %v", author.Id(), user, err)
+	}
+}
@@ -0,0 +1,40 @@
+package collections
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestList(t *testing.T) {
+	app := newTestApp(t)
+	author := newTestUser(t, app, "author@example.com")
+	for i := range 5 {
+		newTestPost(t, app, author, fmt.Sprintf("post-%d", i), func(post *PostsRecord) { post.SetRating(float64(i + 1)) })
+	}
+
+	tests := []struct {
+		opts       ListOptions
+		totalPages int
+		ratings    []float64
+	}{
+		{ListOptions{Sort: []SortOrder{PostsSort.Rating.Desc()}, PerPage: 2}, 3, []float64{5, 4}},
+		{ListOptions{Sort: []SortOrder{PostsSort.Rating.Desc()}, Page: 3, PerPage: 2}, 3, []float64{1}},
+		{ListOptions{Filter: PostsFilter.Rating.Gt(3), Sort: []SortOrder{PostsSort.Rating.Asc()}}, 1, []float64{4, 5}},
+	}
+
+	for _, test := range tests {
+		result, err := Posts_List(app, test.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ratings := make([]float64, len(result.Items))
+		for i, post := range result.Items {
+			ratings[i] = post.Rating()
+		}
+
+		if result.TotalPages != test.totalPages || fmt.Sprint(ratings) != fmt.Sprint(test.ratings) {
+			t.Errorf("%+v: expected %d pages of %v, got %d pages of %v", test.opts, test.totalPages, test.ratings, result.TotalPages, ratings)
+		}
+	}
+}
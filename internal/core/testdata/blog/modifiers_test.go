@@ -0,0 +1,39 @@
+package collections
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestModifiers(t *testing.T) {
+	repo, err := NewFakePostsRepo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	post := repo.New()
+	post.SetTags([]string{"web"})
+	post.AddTags("db")
+	post.PrependTags("go")
+	if !slices.Equal(post.Tags(), []string{"go", "web", "db"}) {
+		t.Errorf("expected tags go, web, db, got %v", post.Tags())
+	}
+
+	post.RemoveTags("web")
+	if !slices.Equal(post.Tags(), []string{"go", "db"}) {
+		t.Errorf("expected tags go, db, got %v", post.Tags())
+	}
+
+	post.SetViews(3)
+	post.IncrementViews(2)
+	post.IncrementViews(-1)
+	if post.Views() != 4 {
+		t.Errorf("expected 4 views, got %f", post.Views())
+	}
+
+	post.AddReviewers("a", "b")
+	post.RemoveReviewers("a")
+	if !slices.Equal(post.Reviewers(), []string{"b"}) {
+		t.Errorf("expected reviewers b, got %v", post.Reviewers())
+	}
+}
@@ -0,0 +1,104 @@
+package collections
+
+import (
+	"errors"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+func TestSaveValidationError(t *testing.T) {
+	app := newTestApp(t)
+
+	post, err := Posts_New(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	post.SetTitle("ab")
+
+	var validationErr *ValidationError
+	if err := post.Save(app); !errors.As(err, &validationErr) {
+		t.Fatalf("expected a *ValidationError, got %T %v", err, err)
+	}
+
+	if validationErr.Collection != CollectionPosts {
+		t.Errorf("expected collection %s, got %s", CollectionPosts, validationErr.Collection)
+	}
+
+	for _, field := range []string{PostsFields.Title, PostsFields.Slug, PostsFields.Author} {
+		if validationErr.Field(field) == nil {
+			t.Errorf("expected an error of %s in %v", field, validationErr.Fields)
+		}
+	}
+
+	if validationErr.Field(PostsFields.Views) != nil {
+		t.Errorf("unexpected error of views %v", validationErr.Field(PostsFields.Views))
+	}
+}
+
+func TestRunInTransaction(t *testing.T) {
+	app := newTestApp(t)
+	author := newTestUser(t, app, "author@example.com")
+
+	var id string
+	err := Posts_RunInTransaction(app, func(tx core.App) error {
+		id = newTestPost(t, tx, author, "rollback", nil).Id()
+		return errors.New("rollback")
+	})
+	if err == nil || err.Error() != "rollback" {
+		t.Fatalf("expected the error of fn, got %v", err)
+	}
+
+	if exists, _ := NewPostsRepo(app).Exists(id); exists {
+		t.Error("post is not rolled back")
+	}
+}
+
+func TestNestedValidationError(t *testing.T) {
+	app := newTestApp(t)
+	author := newTestUser(t, app, "author@example.com")
+
+	Posts_OnValidate(app, func(e *PostsRecordEvent) error {
+		return validation.Errors{PostsFields.Meta: validation.Errors{"title": errors.New("missing")}}
+	})
+
+	post, err := Posts_New(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	post.SetTitle("nested")
+	post.SetSlug("nested")
+	post.SetRating(3)
+	post.SetStatus("draft")
+	post.SetAuthor(author.Id())
+
+	var validationErr *ValidationError
+	if err := post.Validate(app); !errors.As(err, &validationErr) {
+		t.Fatalf("expected a *ValidationError, got %T %v", err, err)
+	}
+
+	if validationErr.Field("meta") == nil || validationErr.Field("meta.title") == nil || len(validationErr.Fields) != 2 {
+		t.Errorf("expected errors of meta and meta.title, got %v", validationErr.Fields)
+	}
+}
+
+// TestDeleteReferenced deletes a user which is the required author of a post
+func TestDeleteReferenced(t *testing.T) {
+	app := newTestApp(t)
+	author := newTestUser(t, app, "author@example.com")
+	post := newTestPost(t, app, author, "hello", nil)
+
+	err := author.Delete(app)
+	var validationErr *ValidationError
+	if err == nil || errors.As(err, &validationErr) {
+		t.Fatalf("expected a plain error, got %T %v", err, err)
+	}
+
+	if err := post.Delete(app); err != nil {
+		t.Fatal(err)
+	}
+	if err := author.Delete(app); err != nil {
+		t.Fatal(err)
+	}
+}
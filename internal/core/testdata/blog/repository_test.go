@@ -0,0 +1,81 @@
+package collections
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+// testRepository runs the same checks against the database and the fake implementation of PostsRepository
+func testRepository(t *testing.T, repo PostsRepository, newPost func(title string) *PostsRecord) {
+	t.Helper()
+
+	var ids []string
+	for i, title := range []string{"alpha", "beta", "gamma"} {
+		post := newPost(title)
+		post.SetViews(float64(10 * i))
+		if err := repo.Save(post); err != nil {
+			t.Fatal(err)
+		}
+		if post.Id() == "" || post.Created().IsZero() {
+			t.Fatalf("%s: id and created are not set on save", title)
+		}
+		ids = append(ids, post.Id())
+	}
+
+	result, err := repo.List(ListOptions{Sort: []SortOrder{PostsSort.Views.Desc()}, PerPage: 2, Page: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TotalItems != 3 || result.TotalPages != 2 || len(result.Items) != 2 || result.Items[0].Title() != "gamma" {
+		t.Errorf("unexpected first page %+v", result)
+	}
+
+	if count, _ := repo.Count(PostsFilter.Views.Gte(10)); count != 2 {
+		t.Errorf("expected 2 posts with 10 views or more, got %d", count)
+	}
+
+	found, err := repo.Find(ids[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	found.SetTitle("changed")
+	if again, _ := repo.Find(ids[0]); again.Title() != "alpha" {
+		t.Errorf("changes are visible before save: %s", again.Title())
+	}
+
+	if err := repo.Save(found); err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := repo.Find(ids[0]); again.Title() != "changed" {
+		t.Errorf("changes are not saved: %s", again.Title())
+	}
+
+	if err := repo.Delete(found); err != nil {
+		t.Fatal(err)
+	}
+	if exists, _ := repo.Exists(ids[0]); exists {
+		t.Error("deleted post exists")
+	}
+	if _, err := repo.Find(ids[0]); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected sql.ErrNoRows for a deleted post, got %v", err)
+	}
+}
+
+func TestRepository(t *testing.T) {
+	app := newTestApp(t)
+	author := newTestUser(t, app, "author@example.com")
+
+	testRepository(t, NewPostsRepo(app), func(title string) *PostsRecord {
+		post, err := Posts_New(app)
+		if err != nil {
+			t.Fatal(err)
+		}
+		post.SetTitle(title)
+		post.SetSlug(title)
+		post.SetRating(3)
+		post.SetStatus("draft")
+		post.SetAuthor(author.Id())
+		return post
+	})
+}
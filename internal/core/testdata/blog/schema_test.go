@@ -0,0 +1,84 @@
+package collections
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+)
+
+func TestVerifySchema(t *testing.T) {
+	app := newTestApp(t)
+	if err := VerifySchema(app); err != nil {
+		t.Fatal(err)
+	}
+
+	collection, err := app.FindCollectionByNameOrId(CollectionPosts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	collection.Indexes = nil
+	collection.Fields.RemoveByName("slug")
+	collection.Fields.GetByName("tags").(*core.SelectField).MaxSelect = 1
+	if err := app.Save(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	err = VerifySchema(app)
+	if err == nil {
+		t.Fatal("expected an error for the changed collection")
+	}
+
+	lines := strings.Split(err.Error(), "\n")
+	if len(lines) != 2 || !strings.Contains(err.Error(), "posts.slug: missing") || !strings.Contains(err.Error(), "posts.tags: multiple is false") {
+		t.Errorf("expected errors of slug and tags, got %v", err)
+	}
+}
+
+// TestImportSchema keeps other collections and fields unless deleteMissing is set
+func TestImportSchema(t *testing.T) {
+	app := newTestApp(t)
+
+	notes := core.NewBaseCollection("notes")
+	notes.Fields.Add(&core.TextField{Name: "text"})
+	if err := app.Save(notes); err != nil {
+		t.Fatal(err)
+	}
+
+	note := core.NewRecord(notes)
+	note.Set("text", "keep")
+	if err := app.Save(note); err != nil {
+		t.Fatal(err)
+	}
+
+	posts, err := app.FindCollectionByNameOrId(CollectionPosts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	posts.Fields.Add(&core.TextField{Name: "legacy"})
+	if err := app.Save(posts); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ImportSchema(app, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.FindRecordById("notes", note.Id); err != nil {
+		t.Errorf("expected the record of the other collection to be kept, got %v", err)
+	}
+	if posts, err = app.FindCollectionByNameOrId(CollectionPosts); err != nil || posts.Fields.GetByName("legacy") == nil {
+		t.Errorf("expected the other field to be kept, got %v", err)
+	}
+
+	if err := ImportSchema(app, true); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.FindCollectionByNameOrId("notes"); err == nil {
+		t.Error("expected the other collection to be deleted")
+	}
+	if posts, err = app.FindCollectionByNameOrId(CollectionPosts); err != nil || posts.Fields.GetByName("legacy") != nil {
+		t.Errorf("expected the other field to be deleted, got %v", err)
+	}
+}
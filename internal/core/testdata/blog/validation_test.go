@@ -0,0 +1,68 @@
+package collections
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestStructValidate(t *testing.T) {
+	tags := []PostsTagsOptions{"go", "web", "db", "x"}
+	website := "http://other.com"
+	large := strings.Repeat("x", DefaultEditorMaxSize+1)
+	meta := map[string]any{"large": large}
+
+	tests := []struct {
+		name   string
+		s      PostsStruct
+		fields []string
+	}{
+		{"valid", PostsStruct{Title: "abc", Slug: "abc", Rating: 3, Status: "draft", Author: "x"}, nil},
+		{
+			"invalid",
+			PostsStruct{Title: "ab", Rating: 7, Status: "bogus", Website: &website, Tags: &tags},
+			[]string{"author", "rating", "slug", "status", "tags", "title", "website"},
+		},
+		{
+			"too large",
+			PostsStruct{Title: "abc", Slug: "abc", Rating: 3, Status: "draft", Author: "x", Body: &large, Meta: &meta},
+			[]string{"body", "meta"},
+		},
+	}
+
+	for _, test := range tests {
+		err := test.s.Validate()
+
+		var fields []string
+		if validationErr, ok := err.(*ValidationError); ok {
+			for field := range validationErr.Fields {
+				fields = append(fields, field)
+			}
+		} else if err != nil {
+			t.Fatalf("%s: expected a *ValidationError, got %T %v", test.name, err, err)
+		}
+
+		slices.Sort(fields)
+		if !slices.Equal(fields, test.fields) {
+			t.Errorf("%s: expected errors of %v, got %v", test.name, test.fields, err)
+		}
+	}
+}
+
+func TestValidateUpload(t *testing.T) {
+	tests := []struct {
+		name  string
+		size  int64
+		valid bool
+	}{
+		{"a.png", 10, true},
+		{"a.jpg", 10, false},
+		{"a.png", 6 << 20, false},
+	}
+
+	for _, test := range tests {
+		if err := Posts_ValidateImagesUpload(test.name, test.size); (err == nil) != test.valid {
+			t.Errorf("%s of %d bytes: expected valid %t, got %v", test.name, test.size, test.valid, err)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package collections
+
+import "testing"
+
+func TestViewRepository(t *testing.T) {
+	app := newTestApp(t)
+	post := newTestPost(t, app, newTestUser(t, app, "author@example.com"), "stats", func(post *PostsRecord) { post.SetViews(5) })
+
+	stats, err := NewPostStatsRepo(app).Find(post.Id())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Title() != "stats" || stats.Views() != 5 {
+		t.Errorf("unexpected view record %s %f", stats.Title(), stats.Views())
+	}
+
+	fake, err := NewFakePostStatsRepo(stats)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count, _ := fake.Count(PostStatsFilter.Views.Eq(5)); count != 1 {
+		t.Errorf("expected the view record in the fake repository, got %d", count)
+	}
+}
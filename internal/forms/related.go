@@ -0,0 +1,26 @@
+package forms
+
+import (
+	"github.com/charmbracelet/huh"
+	"github.com/rs/zerolog/log"
+)
+
+func AskCollectionsRelated(inputValue bool) bool {
+	var collectionsRelated bool = inputValue
+
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title("Include related collections?").
+				Description("Collections referenced by relations of the selected collections, otherwise these relations become plain ids").
+				Value(&collectionsRelated),
+		),
+	)
+
+	err := form.Run()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Related collections form error")
+	}
+
+	return collectionsRelated
+}
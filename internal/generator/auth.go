@@ -0,0 +1,58 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/arturh85/pocketbase-go-generator/internal/cmd"
+)
+
+// authRuntime is emitted once per generated file and lists the auth methods every auth record inherits from core.Record
+const authRuntime = `
+// AuthRecord is implemented by the records of all auth collections, the methods are inherited from core.Record
+type AuthRecord interface {
+	core.RecordProxy
+	Email() string
+	SetEmail(email string)
+	EmailVisibility() bool
+	SetEmailVisibility(visible bool)
+	Verified() bool
+	SetVerified(verified bool)
+	SetPassword(password string)
+	ValidatePassword(password string) bool
+	TokenKey() string
+	RefreshTokenKey()
+	NewAuthToken() (string, error)
+}
+`
+
+func GetGoAuthRuntime() string {
+	return authRuntime
+}
+
+func (collection CollectionWithProperties) GetGoAuthHelpers(generatorFlags *cmd.GeneratorFlags) string {
+	if collection.Collection.Type != "auth" {
+		return ""
+	}
+
+	template := `
+var _ AuthRecord = (*$$$Record)(nil)
+
+func $$$_FindAuthRecordByEmail(app core.App, email string) (*$$$Record, error) {
+	_record, err := app.FindAuthRecordByEmail(Collection$$$, email)
+	if err != nil { return nil, err }
+	return $$$_Wrap(_record), nil
+}
+
+// $$$_FindAuthRecordByToken finds the record an auth token was issued for, tokens of other collections are rejected
+func $$$_FindAuthRecordByToken(app core.App, token string) (*$$$Record, error) {
+	_record, err := app.FindAuthRecordByToken(token, core.TokenTypeAuth)
+	if err != nil { return nil, err }
+	if _record.Collection().Name != Collection$$$ {
+		return nil, fmt.Errorf("auth token belongs to collection %s instead of %s", _record.Collection().Name, Collection$$$)
+	}
+	return $$$_Wrap(_record), nil
+}
+`
+
+	return strings.ReplaceAll(template, "$$$", collection.GoName)
+}
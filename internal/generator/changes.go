@@ -0,0 +1,101 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arturh85/pocketbase-go-generator/internal/cmd"
+)
+
+// changesRuntime is emitted once per generated file and contains the helpers of the generated XxxChanged methods
+const changesRuntime = `
+// jsonChanged compares the values of a json field by their json encoding
+func jsonChanged(original any, value any) bool {
+	originalJSON, _ := json.Marshal(original)
+	valueJSON, _ := json.Marshal(value)
+	return string(originalJSON) != string(valueJSON)
+}
+`
+
+func GetGoChangesRuntime() string {
+	return changesRuntime
+}
+
+// getGoChangedCondition returns the expression comparing the original with the current value of the property
+func (property InterfaceProperty) getGoChangedCondition(original string, value string) string {
+	switch {
+	case property.IsArray:
+		return fmt.Sprintf("!slices.Equal(%s, %s)", original, value)
+	case property.Type == IptDate:
+		return fmt.Sprintf("!%s.Equal(%s)", original, value)
+	case property.Type == IptJson:
+		return fmt.Sprintf("jsonChanged(%s, %s)", original, value)
+	default:
+		return fmt.Sprintf("%s != %s", original, value)
+	}
+}
+
+/*
+example change tracking:
+
+	func (a *PostsRecord) OriginalStatus() string {
+	    return a.Original().GetString("status")
+	}
+
+	func (a *PostsRecord) StatusChanged() bool {
+	    return a.OriginalStatus() != a.Status()
+	}
+*/
+func (property InterfaceProperty) GetGoRecordChanges(generatorFlags *cmd.GeneratorFlags, flags propertyFlags) string {
+	original := fmt.Sprintf("a.Original%s()", property.MethodName)
+	value := fmt.Sprintf("a.%s()", property.MethodName)
+
+	return fmt.Sprintf(`
+// Original%[2]s returns %[3]s as it was loaded from the database, the zero value for new records
+func (a *%[1]sRecord) Original%[2]s() %[4]s {
+	return a.Original().%[5]s(%[3]q)
+}
+
+// %[2]sChanged reports whether %[3]s differs from the value loaded from the database
+func (a *%[1]sRecord) %[2]sChanged() bool {
+	return %[6]s
+}
+`,
+		property.CollectionGoName,
+		property.MethodName,
+		property.getGoName(generatorFlags, flags),
+		property.getGoRecordType(flags),
+		property.getPocketbaseGetter(flags),
+		property.getGoChangedCondition(original, value),
+	)
+}
+
+/*
+example changed fields:
+
+	func (a *PostsRecord) ChangedFields() []string {
+	    var fields []string
+	    if a.TitleChanged() { fields = append(fields, PostsFields.Title) }
+	    ...
+	}
+*/
+func (collection CollectionWithProperties) GetGoRecordChangedFields(generatorFlags *cmd.GeneratorFlags) string {
+	flags := propertyFlags{forceOptional: false, relationAsString: true}
+
+	changes := make([]string, len(collection.Properties))
+	checks := make([]string, len(collection.Properties))
+	for i, property := range collection.Properties {
+		changes[i] = property.GetGoRecordChanges(generatorFlags, flags)
+		checks[i] = fmt.Sprintf("\tif a.%sChanged() { fields = append(fields, %sFields.%s) }", property.MethodName, collection.GoName, property.GoName)
+	}
+
+	return strings.Join(changes, "") + fmt.Sprintf(`
+// ChangedFields returns the names of the fields which differ from the values loaded from the database, all fields
+// with a value are changed for new records
+func (a *%[1]sRecord) ChangedFields() []string {
+	var fields []string
+%[2]s
+	return fields
+}
+`, collection.GoName, strings.Join(checks, "\n"))
+}
@@ -0,0 +1,221 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arturh85/pocketbase-go-generator/internal/cmd"
+)
+
+// convertRuntime is emitted once per generated file and contains the helpers of the generated ToStruct and
+// Xxx_FromStruct conversions
+const convertRuntime = `
+func ptr[T any](value T) *T {
+	return &value
+}
+
+// convertStrings converts the values of a multiple select field to its enum type
+func convertStrings[T ~string](values []string) []T {
+	output := make([]T, len(values))
+	for i, value := range values { output[i] = T(value) }
+	return output
+}
+
+// structJSON converts the value of a json field to the map used by the XxxStruct types, it fails if the value is
+// not a json object
+func structJSON(name string, value any) (map[string]any, error) {
+	raw, ok := value.(types.JSONRaw)
+	if !ok {
+		var err error
+		if raw, err = json.Marshal(value); err != nil { return nil, fmt.Errorf("%s: %w", name, err) }
+	}
+
+	var output map[string]any
+	if len(raw) == 0 { return output, nil }
+	if err := json.Unmarshal(raw, &output); err != nil { return nil, fmt.Errorf("%s: %w", name, err) }
+	return output, nil
+}
+`
+
+func GetGoConvertRuntime() string {
+	return convertRuntime
+}
+
+// isGoStructPointer reports whether the property is a pointer in XxxStruct
+func (property InterfaceProperty) isGoStructPointer() bool {
+	return strings.HasPrefix(property.getGoTypeWithArray(propertyFlags{forceOptional: false, relationAsString: true}), "*")
+}
+
+// getGoStructConversion returns the expression converting the typed getter of the property to its XxxStruct type,
+// json fields are converted with structJSON instead
+func (property InterfaceProperty) getGoStructConversion() string {
+	value := fmt.Sprintf("a.%s()", property.MethodName)
+
+	switch {
+	case property.Type == IptEnum && property.IsArray:
+		value = fmt.Sprintf("convertStrings[%s](%s)", property.getGoEnumName(), value)
+	case property.Type == IptEnum:
+		value = fmt.Sprintf("%s(%s)", property.getGoEnumName(), value)
+	case property.Type == IptNumber:
+		value = fmt.Sprintf("float32(%s)", value)
+	case property.Type == IptDate:
+		value += ".String()"
+	}
+
+	if property.isGoStructPointer() {
+		value = fmt.Sprintf("ptr(%s)", value)
+	}
+
+	return value
+}
+
+// getGoStructExpand returns the statements converting the expanded records of the relation to XxxStruct.Expand
+func (property InterfaceProperty) getGoStructExpand() string {
+	target := property.RelationTarget.GoName + "Struct"
+	pointer := property.Optional || property.RecursiveRelation
+
+	if property.IsArray {
+		value := "values"
+		if property.Optional {
+			value = "&values"
+		}
+
+		return fmt.Sprintf(`
+	if records := a.Expanded%[1]s(); len(records) > 0 {
+		values := make([]%[2]s, len(records))
+		for i, record := range records {
+			value, err := record.ToStruct()
+			if err != nil { return s, err }
+			values[i] = value
+		}
+		s.Expand.%[3]s = %[4]s
+	}`, property.MethodName, target, property.GoName, value)
+	}
+
+	value := "value"
+	if pointer {
+		value = "&value"
+	}
+
+	return fmt.Sprintf(`
+	if record := a.Expanded%[1]s(); record != nil {
+		value, err := record.ToStruct()
+		if err != nil { return s, err }
+		s.Expand.%[2]s = %[3]s
+	}`, property.MethodName, property.GoName, value)
+}
+
+// getGoStructSetValue returns the value of the property in XxxStruct passed to record.Set by Xxx_FromStruct
+func (property InterfaceProperty) getGoStructSetValue() string {
+	value := property.getGoStructValue()
+
+	switch {
+	case property.Type == IptEnum && property.IsArray:
+		return fmt.Sprintf("nonEmpty(%s...)", value)
+	case property.Type == IptEnum:
+		return fmt.Sprintf("string(%s)", value)
+	default:
+		return value
+	}
+}
+
+/*
+example conversions:
+
+	func (a *PostsRecord) ToStruct() (PostsStruct, error) {
+	    s := PostsStruct{
+	        Title: a.Title(),
+	        Views: ptr(float32(a.Views())),
+	    }
+	    ...
+	}
+*/
+func (collection CollectionWithProperties) GetGoConversions(generatorFlags *cmd.GeneratorFlags) string {
+	var fields, conversions []string
+
+	for _, property := range collection.Properties {
+		name := property.getGoName(generatorFlags, propertyFlags{forceOptional: false, relationAsString: true})
+
+		switch {
+		case property.Type == IptJson:
+			value := "value"
+			if property.Optional {
+				value = "&value"
+			}
+			conversions = append(conversions, fmt.Sprintf(`
+	if value, err := structJSON(%[1]q, a.%[2]s()); err != nil {
+		return s, err
+	} else if value != nil {
+		s.%[3]s = %[4]s
+	}`, name, property.MethodName, property.GoName, value))
+		case property.CoreAccessors && name == "email":
+			// like PublicExport, the email is only exported if it is visible
+			conversions = append(conversions, fmt.Sprintf(`
+	if a.EmailVisibility() { s.%s = %s }`, property.GoName, property.getGoStructConversion()))
+		default:
+			fields = append(fields, fmt.Sprintf("\t\t%s: %s,", property.GoName, property.getGoStructConversion()))
+		}
+
+		if property.Type == IptRelation && property.RelationTarget != nil {
+			conversions = append(conversions, property.getGoStructExpand())
+		}
+	}
+
+	return fmt.Sprintf(`
+// ToStruct converts the record and its expanded relations field by field, it is a faster replacement of
+// PublicExportStruct which also reports json fields which can not be converted.
+func (a *%[1]sRecord) ToStruct() (%[1]sStruct, error) {
+	s := %[1]sStruct{
+%[2]s
+	}
+%[3]s
+
+	return s, nil
+}
+`,
+		collection.GoName,
+		strings.Join(fields, "\n"),
+		strings.Join(conversions, ""),
+	)
+}
+
+/*
+example from struct:
+
+	func Posts_FromStruct(app core.App, s PostsStruct) (*PostsRecord, error) {
+	    c, err := app.FindCollectionByNameOrId(CollectionPosts)
+	    ...
+	    record.Set("title", s.Title)
+	    ...
+	}
+*/
+func (collection CollectionWithProperties) GetGoFromStruct(generatorFlags *cmd.GeneratorFlags) string {
+	sets := make([]string, len(collection.Properties))
+
+	for i, property := range collection.Properties {
+		name := property.getGoName(generatorFlags, propertyFlags{forceOptional: false, relationAsString: true})
+
+		// autodate fields ignore record.Set
+		if property.FieldType == "autodate" {
+			sets[i] = fmt.Sprintf("\tif value, err := types.ParseDateTime(%s); err == nil { record.SetRaw(%q, value) }", property.getGoStructValue(), name)
+		} else {
+			sets[i] = fmt.Sprintf("\trecord.Set(%q, %s)", name, property.getGoStructSetValue())
+		}
+	}
+
+	return fmt.Sprintf(`
+// %[1]s_FromStruct creates an unsaved record with the fields of s, s.Expand is ignored
+func %[1]s_FromStruct(app core.App, s %[1]sStruct) (*%[1]sRecord, error) {
+	c, err := app.FindCollectionByNameOrId(Collection%[1]s)
+	if err != nil { return nil, err }
+
+	record := core.NewRecord(c)
+%[2]s
+
+	return %[1]s_Wrap(record), nil
+}
+`,
+		collection.GoName,
+		strings.Join(sets, "\n"),
+	)
+}
@@ -0,0 +1,146 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arturh85/pocketbase-go-generator/internal/cmd"
+)
+
+// isGoInputWritable reports whether the property is part of the XxxCreate and XxxUpdate types
+func (property InterfaceProperty) isGoInputWritable() bool {
+	return !property.IsReadOnly()
+}
+
+// isGoInputNillable reports whether the input type of the property can be nil and needs no pointer to be optional
+func (property InterfaceProperty) isGoInputNillable() bool {
+	return property.IsArray || property.Type == IptFile || property.Type == IptJson
+}
+
+// getGoInputType returns the type of the property in XxxCreate and XxxUpdate, files are uploads and selects use the
+// enum type
+func (property InterfaceProperty) getGoInputType(flags propertyFlags) string {
+	switch {
+	case property.Type == IptFile && property.IsArray:
+		return "[]*filesystem.File"
+	case property.Type == IptFile:
+		return "*filesystem.File"
+	case property.Type == IptEnum && property.IsArray:
+		return "[]" + property.getGoEnumName()
+	case property.Type == IptEnum:
+		return property.getGoEnumName()
+	default:
+		return property.getGoRecordType(flags)
+	}
+}
+
+// getGoInputValue converts the input value expression to the value passed to record.Set
+func (property InterfaceProperty) getGoInputValue(value string) string {
+	switch {
+	case property.Type == IptEnum && property.IsArray:
+		return fmt.Sprintf("nonEmpty(%s...)", value)
+	case property.Type == IptEnum:
+		return fmt.Sprintf("string(%s)", value)
+	default:
+		return value
+	}
+}
+
+// getGoInputField returns the struct field and the ApplyTo statement of the property, optional fields are pointers
+// unless their type is nillable
+func (property InterfaceProperty) getGoInputField(generatorFlags *cmd.GeneratorFlags, flags propertyFlags, optional bool) (string, string) {
+	name := property.getGoName(generatorFlags, flags)
+	inputType := property.getGoInputType(flags)
+	tag := name
+	if optional || property.isGoInputNillable() {
+		tag += ",omitempty"
+	}
+	// uploads can not be decoded from json
+	if property.Type == IptFile {
+		tag = "-"
+	}
+
+	value := "d." + property.GoName
+
+	switch {
+	case property.isGoInputNillable():
+		return fmt.Sprintf("    %s %s `json:\"%s\"`", property.GoName, inputType, tag),
+			fmt.Sprintf("\tif %s != nil { record.Set(%q, %s) }", value, name, property.getGoInputValue(value))
+	case optional:
+		return fmt.Sprintf("    %s *%s `json:\"%s\"`", property.GoName, inputType, tag),
+			fmt.Sprintf("\tif %s != nil { record.Set(%q, %s) }", value, name, property.getGoInputValue("*"+value))
+	default:
+		return fmt.Sprintf("    %s %s `json:\"%s\"`", property.GoName, inputType, tag),
+			fmt.Sprintf("\trecord.Set(%q, %s)", name, property.getGoInputValue(value))
+	}
+}
+
+/*
+example input types:
+
+	type PostsCreate struct {
+	    Title string `json:"title"`
+	    Views *float64 `json:"views,omitempty"`
+	}
+
+	type PostsUpdate struct {
+	    Title *string `json:"title,omitempty"`
+	    Views *float64 `json:"views,omitempty"`
+	}
+*/
+func (collection CollectionWithProperties) GetGoInputTypes(generatorFlags *cmd.GeneratorFlags) string {
+	flags := propertyFlags{forceOptional: false, relationAsString: true}
+
+	var createFields, createApply, updateFields, updateApply []string
+
+	for _, property := range collection.Properties {
+		if !property.isGoInputWritable() {
+			continue
+		}
+
+		field, apply := property.getGoInputField(generatorFlags, flags, property.Optional)
+		createFields = append(createFields, field)
+		createApply = append(createApply, apply)
+
+		field, apply = property.getGoInputField(generatorFlags, flags, true)
+		updateFields = append(updateFields, field)
+		updateApply = append(updateApply, apply)
+	}
+
+	// the password of auth collections is hidden and therefore no property
+	if collection.Collection.Type == "auth" {
+		createFields = append(createFields, "    Password string `json:\"password\"`")
+		createApply = append(createApply, "\tif d.Password != \"\" { record.SetPassword(d.Password) }")
+		updateFields = append(updateFields, "    Password *string `json:\"password,omitempty\"`")
+		updateApply = append(updateApply, "\tif d.Password != nil { record.SetPassword(*d.Password) }")
+	}
+
+	return fmt.Sprintf(`
+// %[1]sCreate holds the writable fields of a new %[2]s record, optional fields are only set if they are not nil
+type %[1]sCreate struct {
+%[3]s
+}
+
+// ApplyTo sets the fields on record, use it with the record returned by %[1]s_New
+func (d %[1]sCreate) ApplyTo(record *%[1]sRecord) {
+%[4]s
+}
+
+// %[1]sUpdate holds the writable fields of a %[2]s record for patching, only fields which are not nil are changed
+type %[1]sUpdate struct {
+%[5]s
+}
+
+// ApplyTo sets the fields which are not nil on record
+func (d %[1]sUpdate) ApplyTo(record *%[1]sRecord) {
+%[6]s
+}
+`,
+		collection.GoName,
+		collection.Collection.Name,
+		strings.Join(createFields, "\n"),
+		strings.Join(createApply, "\n"),
+		strings.Join(updateFields, "\n"),
+		strings.Join(updateApply, "\n"),
+	)
+}
@@ -0,0 +1,220 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arturh85/pocketbase-go-generator/internal/cmd"
+)
+
+// fakeRuntime is emitted once per generated file and contains the in-memory storage of all FakeXxxRepo types
+const fakeRuntime = `
+// fakeStore keeps the records of a generated FakeXxxRepo in memory. Records are cloned on the way in and out, so
+// changes only become visible after Save like with a database.
+type fakeStore struct {
+	collection *core.Collection
+	ids        []string
+	records    map[string]*core.Record
+}
+
+func newFakeStore(collection *core.Collection) *fakeStore {
+	return &fakeStore{collection: collection, records: map[string]*core.Record{}}
+}
+
+func (s *fakeStore) find(id string) (*core.Record, error) {
+	record, ok := s.records[id]
+	if !ok { return nil, sql.ErrNoRows }
+	return record.Clone(), nil
+}
+
+// filter returns clones of all records matching filter in insertion order
+func (s *fakeStore) filter(filter Filter) []*core.Record {
+	var records []*core.Record
+	for _, id := range s.ids {
+		if record := s.records[id]; filter.Match(record) { records = append(records, record.Clone()) }
+	}
+	return records
+}
+
+func (s *fakeStore) list(opts ListOptions) ([]*core.Record, int, int, int64) {
+	page, perPage := opts.pagination()
+	records := s.filter(opts.Filter)
+	sortFakeRecords(records, opts.Sort)
+	start := min((page-1)*perPage, len(records))
+	end := min(start+perPage, len(records))
+	return records[start:end], page, perPage, int64(len(records))
+}
+
+// save stores a clone of record, like the database it assigns a random id and sets the autodate fields
+func (s *fakeStore) save(record *core.Record) error {
+	if record.Id == "" { record.Id = core.GenerateDefaultRandomId() }
+	_, exists := s.records[record.Id]
+	for _, field := range s.collection.Fields {
+		if autodate, ok := field.(*core.AutodateField); ok && (autodate.OnCreate && !exists || autodate.OnUpdate) {
+			record.SetRaw(autodate.Name, types.NowDateTime())
+		}
+	}
+	if err := record.PostScan(); err != nil { return err }
+	if !exists { s.ids = append(s.ids, record.Id) }
+	s.records[record.Id] = record.Clone()
+	return nil
+}
+
+func (s *fakeStore) delete(id string) error {
+	if _, ok := s.records[id]; !ok { return sql.ErrNoRows }
+	delete(s.records, id)
+	s.ids = slices.DeleteFunc(s.ids, func(v string) bool { return v == id })
+	return nil
+}
+
+// sortFakeRecords orders records like the sort expressions of ListOptions, records with equal values keep their order
+func sortFakeRecords(records []*core.Record, orders []SortOrder) {
+	slices.SortStableFunc(records, func(a *core.Record, b *core.Record) int {
+		for _, order := range orders {
+			field, desc := strings.CutPrefix(string(order), "-")
+			field = strings.TrimPrefix(field, "+")
+
+			var compared int
+			switch value := a.Get(field).(type) {
+			case float64:
+				compared = cmp.Compare(value, b.GetFloat(field))
+			default:
+				compared = strings.Compare(a.GetString(field), b.GetString(field))
+			}
+
+			if desc { compared = -compared }
+			if compared != 0 { return compared }
+		}
+		return 0
+	})
+}
+`
+
+func GetGoFakeRuntime() string {
+	return fakeRuntime
+}
+
+// getGoFakeField returns the core.Field literal used for the property in the fake collection
+func (property InterfaceProperty) getGoFakeField() string {
+	switch property.FieldType {
+	case "number":
+		return fmt.Sprintf("&core.NumberField{Name: %q}", property.Name)
+	case "bool":
+		return fmt.Sprintf("&core.BoolField{Name: %q}", property.Name)
+	case "email":
+		return fmt.Sprintf("&core.EmailField{Name: %q}", property.Name)
+	case "url":
+		return fmt.Sprintf("&core.URLField{Name: %q}", property.Name)
+	case "editor":
+		return fmt.Sprintf("&core.EditorField{Name: %q}", property.Name)
+	case "date":
+		return fmt.Sprintf("&core.DateField{Name: %q}", property.Name)
+	case "autodate":
+		return fmt.Sprintf("&core.AutodateField{Name: %q, OnCreate: %t, OnUpdate: %t}", property.Name, property.OnCreate, property.OnUpdate)
+	case "json":
+		return fmt.Sprintf("&core.JSONField{Name: %q}", property.Name)
+	case "select":
+		return fmt.Sprintf("&core.SelectField{Name: %q, Values: %#v, MaxSelect: %d}", property.Name, property.Data, property.MaxSelect)
+	case "file":
+		return fmt.Sprintf("&core.FileField{Name: %q, MaxSelect: %d}", property.Name, property.MaxSelect)
+	case "relation":
+		return fmt.Sprintf("&core.RelationField{Name: %q, MaxSelect: %d}", property.Name, property.MaxSelect)
+	default:
+		return fmt.Sprintf("&core.TextField{Name: %q}", property.Name)
+	}
+}
+
+/*
+example fake repository:
+
+	type FakePostsRepo struct {
+	    store *fakeStore
+	}
+
+	func NewFakePostsRepo(records ...*PostsRecord) (*FakePostsRepo, error)
+*/
+func (collection CollectionWithProperties) GetGoFakeRepository(generatorFlags *cmd.GeneratorFlags) string {
+	var fields []string
+
+	for _, property := range collection.Properties {
+		// the id field is part of every collection created by core.NewCollection
+		if property.Name == "id" {
+			continue
+		}
+
+		fields = append(fields, fmt.Sprintf("\t\t%s,", property.getGoFakeField()))
+	}
+
+	collectionType := collection.Collection.Type
+	if collectionType == "" {
+		collectionType = "base"
+	}
+
+	template := `
+// Fake$$$Repo is an in-memory $$$Repository for unit tests of services which should run without a database.
+// Filters and sort orders are evaluated in go, relations are not resolved.
+type Fake$$$Repo struct {
+	store *fakeStore
+}
+
+var _ $$$Repository = (*Fake$$$Repo)(nil)
+
+// NewFake$$$Repo creates a Fake$$$Repo containing records, records without id get a random one
+func NewFake$$$Repo(records ...*$$$Record) (*Fake$$$Repo, error) {
+	r := &Fake$$$Repo{store: newFakeStore(newFake$$$Collection())}
+	for _, record := range records {
+		if err := r.store.save(record.ProxyRecord()); err != nil { return nil, err }
+	}
+	return r, nil
+}
+
+func (r *Fake$$$Repo) Find(id string) (*$$$Record, error) {
+	record, err := r.store.find(id)
+	if err != nil { return nil, err }
+	return $$$_Wrap(record), nil
+}
+
+func (r *Fake$$$Repo) List(opts ListOptions) (ListResult[*$$$Record], error) {
+	_records, page, perPage, total := r.store.list(opts)
+	records := make([]*$$$Record, len(_records))
+	for i, _record := range _records { records[i] = $$$_Wrap(_record) }
+	return newListResult(records, page, perPage, total), nil
+}
+
+func (r *Fake$$$Repo) Count(filter Filter) (int64, error) {
+	return int64(len(r.store.filter(filter))), nil
+}
+
+func (r *Fake$$$Repo) Exists(id string) (bool, error) {
+	_, ok := r.store.records[id]
+	return ok, nil
+}
+
+func newFake$$$Collection() *core.Collection {
+	collection := core.NewCollection(%q, Collection$$$)
+	collection.Fields.Add(
+%s
+	)
+	return collection
+}
+`
+
+	return fmt.Sprintf(strings.ReplaceAll(template, "$$$", collection.GoName), collectionType, strings.Join(fields, "\n"))
+}
+
+func (collection CollectionWithProperties) GetGoFakeRepositoryWriters(generatorFlags *cmd.GeneratorFlags) string {
+	return strings.ReplaceAll(`
+// New creates an unsaved record of the fake collection, use it instead of $$$_New which needs an app
+func (r *Fake$$$Repo) New() *$$$Record {
+	return $$$_Wrap(core.NewRecord(r.store.collection))
+}
+
+func (r *Fake$$$Repo) Save(record *$$$Record) error {
+	return r.store.save(record.ProxyRecord())
+}
+
+func (r *Fake$$$Repo) Delete(record *$$$Record) error {
+	return r.store.delete(record.ProxyRecord().Id)
+}
+`, "$$$", collection.GoName)
+}
@@ -0,0 +1,200 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arturh85/pocketbase-go-generator/internal/cmd"
+)
+
+// filesRuntime is emitted once per generated file and is used by the generated XxxURL methods of file fields
+const filesRuntime = `
+// fileURL builds the url pocketbase serves the file of a record at, thumb is only added if it is not empty
+func fileURL(baseURL string, collectionName string, recordId string, name string, thumb string) string {
+	output := strings.TrimRight(baseURL, "/") + "/api/files/" + url.PathEscape(collectionName) + "/" + url.PathEscape(recordId) + "/" + url.PathEscape(name)
+	if thumb != "" { output += "?thumb=" + url.QueryEscape(thumb) }
+	return output
+}
+`
+
+func GetGoFilesRuntime() string {
+	return filesRuntime
+}
+
+// UsesFileUploads reports whether the generated code of the collection uploads files, which is the case for its file
+// fields
+func (collection CollectionWithProperties) UsesFileUploads() bool {
+	for _, property := range collection.Properties {
+		if property.Type == IptFile {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (property InterfaceProperty) getGoThumbName(thumbName string) string {
+	return property.CollectionGoName + property.GoName + "Thumb" + thumbName
+}
+
+/*
+example file helpers:
+
+	const (
+	    UsersAvatarThumb100x100 = "100x100"
+	)
+
+	func (a *UsersRecord) AvatarURL(baseURL string, thumb string) string
+*/
+func (property InterfaceProperty) GetGoRecordFileHelpers(generatorFlags *cmd.GeneratorFlags, flags propertyFlags) string {
+	var output []string
+
+	if len(property.Thumbs) > 0 {
+		thumbs := make([]string, len(property.Thumbs))
+		for i, thumb := range property.Thumbs {
+			// thumb sizes like "100x100t" only contain letters and digits
+			thumbs[i] = fmt.Sprintf("    %s = %q", property.getGoThumbName(thumb), thumb)
+		}
+
+		output = append(output, fmt.Sprintf("\n// thumb sizes of %s.%s for %sURL\nconst (\n%s\n)\n",
+			property.CollectionName,
+			property.Name,
+			property.MethodName,
+			strings.Join(thumbs, "\n"),
+		))
+	}
+
+	name := property.getGoName(generatorFlags, flags)
+
+	if property.IsArray {
+		output = append(output, fmt.Sprintf(`
+// %[2]sURL returns the urls of all %[3]s files, thumb is empty or one of the thumb sizes of the field
+func (a *%[1]sRecord) %[2]sURL(baseURL string, thumb string) []string {
+	names := a.GetStringSlice("%[3]s")
+	urls := make([]string, len(names))
+	for i, name := range names { urls[i] = fileURL(baseURL, Collection%[1]s, a.ProxyRecord().Id, name, thumb) }
+	return urls
+}
+`, property.CollectionGoName, property.MethodName, name))
+	} else {
+		output = append(output, fmt.Sprintf(`
+// %[2]sURL returns the url of the %[3]s file, thumb is empty or one of the thumb sizes of the field. The url is
+// empty if no file is set.
+func (a *%[1]sRecord) %[2]sURL(baseURL string, thumb string) string {
+	name := a.GetString("%[3]s")
+	if name == "" { return "" }
+	return fileURL(baseURL, Collection%[1]s, a.ProxyRecord().Id, name, thumb)
+}
+`, property.CollectionGoName, property.MethodName, name))
+	}
+
+	return strings.Join(output, "")
+}
+
+/*
+example file uploads:
+
+	func (a *UsersRecord) SetAvatarFromPath(path string) error
+	func (a *UsersRecord) SetAvatarFromBytes(name string, data []byte) error
+	func (a *UsersRecord) DeleteAvatar(names ...string)
+	func (a *PostsRecord) AddImagesFromPath(paths ...string) error
+	func (a *PostsRecord) AddImagesFromBytes(name string, data []byte) error
+*/
+func (property InterfaceProperty) GetGoRecordFileUploads(generatorFlags *cmd.GeneratorFlags, flags propertyFlags) string {
+	var output []string
+
+	name := property.getGoName(generatorFlags, flags)
+
+	mimeTypes := "all mime types are accepted"
+	if len(property.MimeTypes) > 0 {
+		mimeTypes = "accepted mime types: " + strings.Join(property.MimeTypes, ", ")
+	}
+
+	if property.IsArray {
+		output = append(output, fmt.Sprintf(`
+// Set%[2]sFromPath replaces all files of %[3]s with the files at paths, they are uploaded and the previous files are
+// deleted from the storage on save. Use Add%[2]sFromPath to keep the previous files (%[4]s).
+func (a *%[1]sRecord) Set%[2]sFromPath(paths ...string) error {
+	files := make([]*filesystem.File, len(paths))
+	for i, path := range paths {
+		file, err := filesystem.NewFileFromPath(path)
+		if err != nil { return err }
+		files[i] = file
+	}
+	a.Set("%[3]s", files)
+	return nil
+}
+
+// Set%[2]sFromBytes replaces all files of %[3]s with a file named name containing data, it is uploaded and the
+// previous files are deleted from the storage on save. Use Add%[2]sFromBytes to keep the previous files (%[4]s).
+func (a *%[1]sRecord) Set%[2]sFromBytes(name string, data []byte) error {
+	file, err := filesystem.NewFileFromBytes(data, name)
+	if err != nil { return err }
+	a.Set("%[3]s", file)
+	return nil
+}
+
+// Add%[2]sFromPath appends the files at paths to %[3]s, they are uploaded on save (%[4]s)
+func (a *%[1]sRecord) Add%[2]sFromPath(paths ...string) error {
+	files := make([]*filesystem.File, len(paths))
+	for i, path := range paths {
+		file, err := filesystem.NewFileFromPath(path)
+		if err != nil { return err }
+		files[i] = file
+	}
+	a.Set("%[3]s+", files)
+	return nil
+}
+
+// Add%[2]sFromBytes appends a file named name containing data to %[3]s, it is uploaded on save (%[4]s)
+func (a *%[1]sRecord) Add%[2]sFromBytes(name string, data []byte) error {
+	file, err := filesystem.NewFileFromBytes(data, name)
+	if err != nil { return err }
+	a.Set("%[3]s+", file)
+	return nil
+}
+`, property.CollectionGoName, property.MethodName, name, mimeTypes))
+	} else {
+		output = append(output, fmt.Sprintf(`
+// Set%[2]sFromPath replaces %[3]s with the file at path, it is uploaded and the previous file is deleted from the
+// storage on save (%[4]s)
+func (a *%[1]sRecord) Set%[2]sFromPath(path string) error {
+	file, err := filesystem.NewFileFromPath(path)
+	if err != nil { return err }
+	a.Set("%[3]s", file)
+	return nil
+}
+
+// Set%[2]sFromBytes replaces %[3]s with a file named name containing data, it is uploaded and the previous file is
+// deleted from the storage on save (%[4]s)
+func (a *%[1]sRecord) Set%[2]sFromBytes(name string, data []byte) error {
+	file, err := filesystem.NewFileFromBytes(data, name)
+	if err != nil { return err }
+	a.Set("%[3]s", file)
+	return nil
+}
+`, property.CollectionGoName, property.MethodName, name, mimeTypes))
+	}
+
+	output = append(output, fmt.Sprintf(`
+// Delete%[2]s removes the files named names from %[3]s, they are deleted from the storage on save
+func (a *%[1]sRecord) Delete%[2]s(names ...string) {
+	a.Set("%[3]s-", names)
+}
+`, property.CollectionGoName, property.MethodName, name))
+
+	return strings.Join(output, "")
+}
+
+// GetGoRecordFileUploads returns the upload and delete helpers of all file fields of XxxRecord
+func (collection CollectionWithProperties) GetGoRecordFileUploads(generatorFlags *cmd.GeneratorFlags) string {
+	var output []string
+
+	for _, property := range collection.Properties {
+		if property.Type == IptFile {
+			output = append(output, property.GetGoRecordFileUploads(generatorFlags, propertyFlags{forceOptional: false, relationAsString: true}))
+		}
+	}
+
+	return strings.Join(output, "")
+}
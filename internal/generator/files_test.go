@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/arturh85/pocketbase-go-generator/internal/cmd"
+)
+
+func TestGetGoRecordFileHelpers(t *testing.T) {
+	tests := []struct {
+		name     string
+		property *InterfaceProperty
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "single file",
+			property: &InterfaceProperty{Name: "avatar", Type: IptFile, FieldType: "file", Thumbs: []string{"100x100"}},
+			contains: []string{
+				"func (a *PostsRecord) SetAvatarFromPath(path string) error",
+				"func (a *PostsRecord) SetAvatarFromBytes(name string, data []byte) error",
+				"func (a *PostsRecord) AvatarURL(baseURL string, thumb string) string",
+				"func (a *PostsRecord) DeleteAvatar(names ...string)",
+				`PostsAvatarThumb100x100 = "100x100"`,
+				"the previous file is deleted",
+			},
+			excludes: []string{"AddAvatarFrom"},
+		},
+		{
+			name:     "multiple files",
+			property: &InterfaceProperty{Name: "images", Type: IptFile, FieldType: "file", IsArray: true, MimeTypes: []string{"image/png"}},
+			contains: []string{
+				"func (a *PostsRecord) SetImagesFromPath(paths ...string) error",
+				"func (a *PostsRecord) AddImagesFromPath(paths ...string) error",
+				"func (a *PostsRecord) AddImagesFromBytes(name string, data []byte) error",
+				"func (a *PostsRecord) ImagesURL(baseURL string, thumb string) []string",
+				`a.Set("images+", files)`,
+				`a.Set("images+", file)`,
+				`a.Set("images-", names)`,
+				"replaces all files of images",
+				"accepted mime types: image/png",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		ResolveIdentifiers([]*CollectionWithProperties{newTestCollection("posts", "base", test.property)}, "")
+
+		output := test.property.GetGoRecordFileHelpers(&cmd.GeneratorFlags{}, propertyFlags{}) + test.property.GetGoRecordFileUploads(&cmd.GeneratorFlags{}, propertyFlags{})
+
+		for _, expected := range test.contains {
+			if !strings.Contains(output, expected) {
+				t.Errorf("%s: expected %q in:\n%s", test.name, expected, output)
+			}
+		}
+
+		for _, unexpected := range test.excludes {
+			if strings.Contains(output, unexpected) {
+				t.Errorf("%s: unexpected %q in:\n%s", test.name, unexpected, output)
+			}
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arturh85/pocketbase-go-generator/internal/cmd"
+)
+
+// filterRuntime is emitted once per generated file and contains the types used by all XxxFilter variables
+const filterRuntime = `
+// Filter is a typed pocketbase filter expression, use the generated XxxFilter variables to create one
+type Filter struct {
+	field    string
+	operator string
+	value    any
+	children []Filter
+}
+
+// And combines the filter with others, all of them have to match
+func (f Filter) And(others ...Filter) Filter {
+	return Filter{operator: "&&", children: append([]Filter{f}, others...)}
+}
+
+// Or combines the filter with others, one of them has to match
+func (f Filter) Or(others ...Filter) Filter {
+	return Filter{operator: "||", children: append([]Filter{f}, others...)}
+}
+
+// IsEmpty reports whether the filter has no conditions (the zero value)
+func (f Filter) IsEmpty() bool {
+	return f.operator == ""
+}
+
+// Build renders the filter to a pocketbase filter string, all values are passed as placeholders in params
+func (f Filter) Build() (string, dbx.Params) {
+	params := dbx.Params{}
+	return f.build(params), params
+}
+
+func (f Filter) build(params dbx.Params) string {
+	if f.IsEmpty() {
+		return ""
+	}
+
+	if f.field == "" {
+		var parts []string
+		for _, child := range f.children {
+			if expr := child.build(params); expr != "" {
+				parts = append(parts, "("+expr+")")
+			}
+		}
+		return strings.Join(parts, " "+f.operator+" ")
+	}
+
+	placeholder := fmt.Sprintf("filter%d", len(params))
+	params[placeholder] = f.value
+	return fmt.Sprintf("%s %s {:%s}", f.field, f.operator, placeholder)
+}
+
+func newFilter(field string, operator string, value any) Filter {
+	return Filter{field: field, operator: operator, value: value}
+}
+
+// Match evaluates the filter in memory against record, it is used by the generated FakeXxxRepo types and does
+// not resolve relations
+func (f Filter) Match(record *core.Record) bool {
+	if f.IsEmpty() {
+		return true
+	}
+
+	if f.field == "" {
+		for _, child := range f.children {
+			if child.Match(record) == (f.operator == "||") {
+				return f.operator == "||"
+			}
+		}
+		return f.operator == "&&"
+	}
+
+	// like in pocketbase, fields without items never match != with :each
+	if name, ok := strings.CutSuffix(f.field, ":each"); ok {
+		items := record.GetStringSlice(name)
+		if f.operator == "?=" { return slices.Contains(items, fmt.Sprint(f.value)) }
+		return len(items) > 0 && !slices.Contains(items, fmt.Sprint(f.value))
+	}
+
+	var compared int
+	switch value := f.value.(type) {
+	case float64:
+		compared = cmp.Compare(record.GetFloat(f.field), value)
+	case bool:
+		if record.GetBool(f.field) != value { compared = 1 }
+	default:
+		switch f.operator {
+		case "~":
+			return matchLike(record.GetString(f.field), fmt.Sprint(value))
+		case "!~":
+			return !matchLike(record.GetString(f.field), fmt.Sprint(value))
+		}
+		compared = strings.Compare(record.GetString(f.field), fmt.Sprint(value))
+	}
+
+	switch f.operator {
+	case "=", "?=":
+		return compared == 0
+	case "!=":
+		return compared != 0
+	case ">":
+		return compared > 0
+	case ">=":
+		return compared >= 0
+	case "<":
+		return compared < 0
+	case "<=":
+		return compared <= 0
+	}
+
+	return false
+}
+
+// matchLike mirrors the pocketbase ~ operator, a pattern without % matches values containing it literally
+func matchLike(value string, pattern string) bool {
+	if !strings.Contains(pattern, "%") {
+		return strings.Contains(strings.ToLower(value), strings.ToLower(pattern))
+	}
+
+	expr := strings.NewReplacer("%", ".*", "_", ".").Replace(regexp.QuoteMeta(pattern))
+	matched, _ := regexp.MatchString("(?is)^"+expr+"$", value)
+	return matched
+}
+
+type StringFilterField struct{ name string }
+
+func (f StringFilterField) Eq(value string) Filter      { return newFilter(f.name, "=", value) }
+func (f StringFilterField) Neq(value string) Filter     { return newFilter(f.name, "!=", value) }
+func (f StringFilterField) Like(value string) Filter    { return newFilter(f.name, "~", value) }
+func (f StringFilterField) NotLike(value string) Filter { return newFilter(f.name, "!~", value) }
+
+type NumberFilterField struct{ name string }
+
+func (f NumberFilterField) Eq(value float64) Filter  { return newFilter(f.name, "=", value) }
+func (f NumberFilterField) Neq(value float64) Filter { return newFilter(f.name, "!=", value) }
+func (f NumberFilterField) Gt(value float64) Filter  { return newFilter(f.name, ">", value) }
+func (f NumberFilterField) Gte(value float64) Filter { return newFilter(f.name, ">=", value) }
+func (f NumberFilterField) Lt(value float64) Filter  { return newFilter(f.name, "<", value) }
+func (f NumberFilterField) Lte(value float64) Filter { return newFilter(f.name, "<=", value) }
+
+type BoolFilterField struct{ name string }
+
+func (f BoolFilterField) Eq(value bool) Filter { return newFilter(f.name, "=", value) }
+
+type DateFilterField struct{ name string }
+
+func (f DateFilterField) Eq(value types.DateTime) Filter  { return newFilter(f.name, "=", value.String()) }
+func (f DateFilterField) Neq(value types.DateTime) Filter { return newFilter(f.name, "!=", value.String()) }
+func (f DateFilterField) Gt(value types.DateTime) Filter  { return newFilter(f.name, ">", value.String()) }
+func (f DateFilterField) Gte(value types.DateTime) Filter { return newFilter(f.name, ">=", value.String()) }
+func (f DateFilterField) Lt(value types.DateTime) Filter  { return newFilter(f.name, "<", value.String()) }
+func (f DateFilterField) Lte(value types.DateTime) Filter { return newFilter(f.name, "<=", value.String()) }
+
+type EnumFilterField[T ~string] struct{ name string }
+
+func (f EnumFilterField[T]) Eq(value T) Filter  { return newFilter(f.name, "=", string(value)) }
+func (f EnumFilterField[T]) Neq(value T) Filter { return newFilter(f.name, "!=", string(value)) }
+
+// multiple value fields are compared per item, Has matches if any item equals the value and HasNot if there are
+// items and none of them does
+type MultiEnumFilterField[T ~string] struct{ name string }
+
+func (f MultiEnumFilterField[T]) Has(value T) Filter    { return newFilter(f.name+":each", "?=", string(value)) }
+func (f MultiEnumFilterField[T]) HasNot(value T) Filter { return newFilter(f.name+":each", "!=", string(value)) }
+
+type RelationFilterField struct{ name string }
+
+func (f RelationFilterField) Eq(id string) Filter  { return newFilter(f.name, "=", id) }
+func (f RelationFilterField) Neq(id string) Filter { return newFilter(f.name, "!=", id) }
+func (f RelationFilterField) Has(id string) Filter { return newFilter(f.name, "?=", id) }
+
+type MultiValueFilterField struct{ name string }
+
+func (f MultiValueFilterField) Has(value string) Filter    { return newFilter(f.name+":each", "?=", value) }
+func (f MultiValueFilterField) HasNot(value string) Filter { return newFilter(f.name+":each", "!=", value) }
+`
+
+func GetGoFilterRuntime() string {
+	return filterRuntime
+}
+
+// getGoFilterFieldType returns the filter field type for the property, empty if it can not be filtered
+func (property InterfaceProperty) getGoFilterFieldType() string {
+	switch property.Type {
+	case IptNumber:
+		return "NumberFilterField"
+	case IptBoolean:
+		return "BoolFilterField"
+	case IptDate:
+		return "DateFilterField"
+	case IptJson:
+		return ""
+	case IptEnum:
+		if property.IsArray {
+			return fmt.Sprintf("MultiEnumFilterField[%s]", property.getGoEnumName())
+		}
+		return fmt.Sprintf("EnumFilterField[%s]", property.getGoEnumName())
+	case IptRelation:
+		if property.IsArray {
+			return "MultiValueFilterField"
+		}
+		return "RelationFilterField"
+	default:
+		if property.IsArray {
+			return "MultiValueFilterField"
+		}
+		return "StringFilterField"
+	}
+}
+
+/*
+example filter:
+
+	var PostsFilter = struct {
+	    Title StringFilterField
+	}{
+	    Title: StringFilterField{name: "title"},
+	}
+*/
+func (collection CollectionWithProperties) GetGoFilter(generatorFlags *cmd.GeneratorFlags) string {
+	var fieldTypes []string
+	var fieldValues []string
+
+	for _, property := range collection.Properties {
+		filterFieldType := property.getGoFilterFieldType()
+		if filterFieldType == "" {
+			continue
+		}
+
+		fieldTypes = append(fieldTypes, fmt.Sprintf("    %s %s", property.GoName, filterFieldType))
+		fieldValues = append(fieldValues, fmt.Sprintf("    %s: %s{name: \"%s\"},", property.GoName, filterFieldType, property.Name))
+	}
+
+	template := `
+func $$$_FindRecordsWhere(app core.App, filter Filter, sort string, limit int, offset int) ([]*$$$Record, error) {
+	expr, params := filter.Build()
+	return $$$_FindRecordsByFilter(app, expr, sort, limit, offset, params)
+}
+`
+
+	return fmt.Sprintf("var %sFilter = struct {\n%s\n}{\n%s\n}\n%s",
+		collection.GoName,
+		strings.Join(fieldTypes, "\n"),
+		strings.Join(fieldValues, "\n"),
+		strings.ReplaceAll(template, "$$$", collection.GoName),
+	)
+}
@@ -0,0 +1,87 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arturh85/pocketbase-go-generator/internal/cmd"
+)
+
+// recordHook is a pocketbase app.OnRecordXxx hook which can be bound per collection
+type recordHook struct {
+	// Name is the part after "OnRecord", it is also used for the generated Xxx_OnName function
+	Name string
+	// Event is the name of the core event type
+	Event string
+}
+
+// viewRecordHooks are the hooks of records which are only read
+var viewRecordHooks = []recordHook{
+	{Name: "ViewRequest", Event: "RecordRequestEvent"},
+}
+
+// recordHooks are the hooks of records which are created, updated or deleted
+var recordHooks = []recordHook{
+	{Name: "Validate", Event: "RecordEvent"},
+	{Name: "Create", Event: "RecordEvent"},
+	{Name: "CreateExecute", Event: "RecordEvent"},
+	{Name: "AfterCreateSuccess", Event: "RecordEvent"},
+	{Name: "AfterCreateError", Event: "RecordErrorEvent"},
+	{Name: "Update", Event: "RecordEvent"},
+	{Name: "UpdateExecute", Event: "RecordEvent"},
+	{Name: "AfterUpdateSuccess", Event: "RecordEvent"},
+	{Name: "AfterUpdateError", Event: "RecordErrorEvent"},
+	{Name: "Delete", Event: "RecordEvent"},
+	{Name: "DeleteExecute", Event: "RecordEvent"},
+	{Name: "AfterDeleteSuccess", Event: "RecordEvent"},
+	{Name: "AfterDeleteError", Event: "RecordErrorEvent"},
+	{Name: "CreateRequest", Event: "RecordRequestEvent"},
+	{Name: "UpdateRequest", Event: "RecordRequestEvent"},
+	{Name: "DeleteRequest", Event: "RecordRequestEvent"},
+}
+
+/*
+example hook binder:
+
+	type PostsRecordEvent struct {
+	    *core.RecordEvent
+	    Record *PostsRecord
+	}
+
+	func Posts_OnCreate(app core.App, handler func(e *PostsRecordEvent) error) string
+*/
+func (collection CollectionWithProperties) GetGoHooks(generatorFlags *cmd.GeneratorFlags) string {
+	return collection.getGoHooks([]string{"RecordRequestEvent"}, viewRecordHooks)
+}
+
+func (collection CollectionWithProperties) GetGoWriteHooks(generatorFlags *cmd.GeneratorFlags) string {
+	return collection.getGoHooks([]string{"RecordEvent", "RecordErrorEvent"}, recordHooks)
+}
+
+// getGoHooks returns the typed events and the hook binders of hooks
+func (collection CollectionWithProperties) getGoHooks(events []string, hooks []recordHook) string {
+	var output []string
+
+	for _, event := range events {
+		output = append(output, fmt.Sprintf(`
+// %[1]s%[2]s is a core.%[2]s with the typed record, call e.Next() in handlers to continue the hook chain
+type %[1]s%[2]s struct {
+	*core.%[2]s
+	Record *%[1]sRecord
+}
+`, collection.GoName, event))
+	}
+
+	for _, hook := range hooks {
+		output = append(output, fmt.Sprintf(`
+// %[1]s_On%[2]s binds handler to app.OnRecord%[2]s of the %[4]s collection and returns the handler id
+func %[1]s_On%[2]s(app core.App, handler func(e *%[1]s%[3]s) error) string {
+	return app.OnRecord%[2]s(Collection%[1]s).BindFunc(func(e *core.%[3]s) error {
+		return handler(&%[1]s%[3]s{%[3]s: e, Record: %[1]s_Wrap(e.Record)})
+	})
+}
+`, collection.GoName, hook.Name, hook.Event, collection.Collection.Name))
+	}
+
+	return strings.Join(output, "")
+}
@@ -0,0 +1,353 @@
+package generator
+
+import (
+	"fmt"
+	"go/token"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/iancoleman/strcase"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+const DefaultIdentifierSuffix = "Field"
+
+// IdentifierRename reports an identifier which could not be derived from its pocketbase name as is
+type IdentifierRename struct {
+	Collection string
+	Field      string
+	From       string
+	To         string
+	Reason     string
+}
+
+func (rename IdentifierRename) String() string {
+	name := rename.Collection
+	if rename.Field != "" {
+		name += "." + rename.Field
+	}
+
+	return fmt.Sprintf("%s: renamed %s to %s (%s)", name, rename.From, rename.To, rename.Reason)
+}
+
+// generatedRecordMethods are methods generated on every XxxRecord independent of its fields
+var generatedRecordMethods = []string{"PublicExportStruct", "ToStruct", "ChangedFields", "Save", "Delete", "Validate"}
+
+// generatedStructFields are fields and methods generated on the XxxStruct, XxxCreate and XxxUpdate types
+// independent of the collection fields
+var generatedStructFields = []string{"Expand", "Validate", "ApplyTo", "Password"}
+
+// reservedParamNames may not be used as setter parameters, besides go keywords
+var reservedParamNames = []string{"a", "app", "err", "cmp", "core", "dbx", "errors", "filepath", "filesystem", "fmt", "is", "iter", "json", "mime", "regexp", "search", "slices", "sql", "strings", "types", "url", "validation"}
+
+// runtimeNames are declared once in every generated file, no declaration generated for a collection may use them
+var runtimeNames = []string{
+	"AllBatchSize", "AuthRecord", "BoolFilterField", "DateFilterField", "DefaultEditorMaxSize", "DefaultFileMaxSize",
+	"DefaultJSONMaxSize", "DefaultPerPage", "DefaultTextMax", "EnumFilterField", "Filter", "ImportSchema",
+	"ListOptions", "ListResult", "MultiEnumFilterField", "MultiValueFilterField", "NumberFilterField",
+	"RelationFilterField", "SchemaJSON", "SortField", "SortOrder", "StringFilterField", "ValidationError",
+	"VerifySchema",
+}
+
+// collectionDeclarations returns the package level names generated for a collection with the go name goName, besides
+// the Xxx_ functions and the types named after its fields
+func collectionDeclarations(goName string) []string {
+	names := []string{
+		"Collection" + goName,
+		"Fake" + goName + "Repo",
+		"New" + goName + "Repo",
+		"NewFake" + goName + "Repo",
+		"newFake" + goName + "Collection",
+		strcase.ToLowerCamel(goName) + "Repo",
+	}
+
+	for _, suffix := range []string{"Create", "Expanded", "Fields", "Filter", "Record", "RecordErrorEvent", "RecordEvent", "RecordRequestEvent", "Repository", "Sort", "Struct", "Update"} {
+		names = append(names, goName+suffix)
+	}
+
+	return names
+}
+
+// recordMethods returns all methods and promoted fields of core.BaseRecordProxy which a generated method would shadow
+func recordMethods() map[string]string {
+	output := map[string]string{
+		"BaseRecordProxy": "core.BaseRecordProxy field",
+		"Record":          "core.BaseRecordProxy field",
+	}
+
+	proxyType := reflect.TypeOf(&core.BaseRecordProxy{})
+	for i := 0; i < proxyType.NumMethod(); i++ {
+		output[proxyType.Method(i).Name] = "core.Record method"
+	}
+
+	for _, method := range generatedRecordMethods {
+		output[method] = "generated method"
+	}
+
+	return output
+}
+
+// propertyMethodNames lists every method generated on XxxRecord for a property with the given method name
+func (property InterfaceProperty) propertyMethodNames(methodName string) []string {
+	names := []string{methodName, "Original" + methodName, methodName + "Changed"}
+
+	if !property.IsReadOnly() {
+		names = append(names, "Set"+methodName)
+	}
+
+	if property.Type == IptNumber {
+		names = append(names, methodName+"Int", "Increment"+methodName)
+	}
+
+	if property.Type == IptFile {
+		names = append(names, "Set"+methodName+"FromPath", "Set"+methodName+"FromBytes", methodName+"URL", "Delete"+methodName)
+
+		if property.IsArray {
+			names = append(names, "Add"+methodName+"FromPath", "Add"+methodName+"FromBytes")
+		}
+	}
+
+	if property.hasGoRecordModifiers() {
+		names = append(names, "Add"+methodName, "Prepend"+methodName, "Remove"+methodName)
+	}
+
+	if property.Type == IptRelation {
+		names = append(names, "Expand"+methodName, "Expanded"+methodName)
+	}
+
+	return names
+}
+
+// ResolveIdentifiers assigns go identifiers to all collections, properties and enum values. Names which are no
+// valid go identifiers or collide with each other, with core.Record or with the runtime are changed, every change is
+// returned.
+func ResolveIdentifiers(collections []*CollectionWithProperties, suffix string) []IdentifierRename {
+	if suffix == "" {
+		suffix = DefaultIdentifierSuffix
+	}
+
+	var renames []IdentifierRename
+
+	reservedMethods := recordMethods()
+	collectionNames := map[string]bool{}
+
+	declarations := map[string]string{}
+	for _, name := range runtimeNames {
+		declarations[name] = "runtime declaration"
+	}
+
+	for _, collection := range collections {
+		goName, reason := sanitizeIdentifier(strcase.ToCamel(collection.Collection.Name))
+		if collectionNames[goName] {
+			reason = "collides with another collection"
+		}
+		goName = uniqueIdentifier(goName, "", collectionNames)
+
+		// the types and functions generated for the collection may not take the name of another declaration
+		name := goName
+		for i := 1; ; i++ {
+			collision := ""
+			for _, declaration := range collectionDeclarations(name) {
+				if declarations[declaration] != "" {
+					collision = declaration
+					break
+				}
+			}
+
+			if collision == "" {
+				break
+			}
+
+			reason = fmt.Sprintf("%s collides with %s", collision, declarations[collision])
+			if i == 1 {
+				name = goName + suffix
+			} else {
+				name = fmt.Sprintf("%s%s%d", goName, suffix, i)
+			}
+		}
+
+		if name != goName {
+			delete(collectionNames, goName)
+			collectionNames[name] = true
+			goName = name
+		}
+
+		for _, declaration := range collectionDeclarations(goName) {
+			declarations[declaration] = fmt.Sprintf("declaration of collection %s", collection.Collection.Name)
+		}
+
+		if reason != "" {
+			renames = append(renames, IdentifierRename{Collection: collection.Collection.Name, From: strcase.ToCamel(collection.Collection.Name), To: goName, Reason: reason})
+		}
+
+		collection.GoName = goName
+	}
+
+	methodNamesByCollection := map[*CollectionWithProperties]map[string]string{}
+
+	for _, collection := range collections {
+		structNames := map[string]bool{}
+		for _, field := range generatedStructFields {
+			structNames[field] = true
+		}
+
+		methodNames := map[string]string{}
+		for method, reason := range reservedMethods {
+			methodNames[method] = reason
+		}
+		methodNamesByCollection[collection] = methodNames
+
+		for _, property := range collection.Properties {
+			property.CollectionGoName = collection.GoName
+
+			camelName := strcase.ToCamel(property.Name)
+			goName, reason := sanitizeIdentifier(camelName)
+			if structNames[goName] {
+				reason = "collides with another field"
+			}
+			property.GoName = uniqueIdentifier(goName, suffix, structNames)
+
+			if reason != "" {
+				renames = append(renames, IdentifierRename{Collection: collection.Collection.Name, Field: property.Name, From: camelName, To: property.GoName, Reason: reason})
+			}
+
+			methodName := property.GoName
+			for i := 0; !property.CoreAccessors; i++ {
+				reason = ""
+				for _, name := range property.propertyMethodNames(methodName) {
+					if methodNames[name] != "" {
+						reason = fmt.Sprintf("method %s collides with %s", name, methodNames[name])
+						break
+					}
+				}
+
+				if reason == "" {
+					break
+				}
+
+				if i == 0 {
+					methodName = property.GoName + suffix
+				} else {
+					methodName = fmt.Sprintf("%s%s%d", property.GoName, suffix, i+1)
+				}
+
+				renames = append(renames, IdentifierRename{Collection: collection.Collection.Name, Field: property.Name, From: property.GoName, To: methodName, Reason: reason})
+			}
+
+			for _, name := range property.propertyMethodNames(methodName) {
+				if methodNames[name] == "" {
+					methodNames[name] = fmt.Sprintf("method of field %s", property.Name)
+				}
+			}
+
+			property.MethodName = methodName
+
+			property.ParamName = strcase.ToLowerCamel(property.GoName)
+			if token.IsKeyword(property.ParamName) || containsString(reservedParamNames, property.ParamName) {
+				property.ParamName += suffix
+			}
+
+			if property.Type == IptEnum {
+				property.EnumValueNames = resolveEnumValueNames(property.Data.([]string))
+			}
+		}
+	}
+
+	// back relations are resolved last, so they never take the name of a getter or setter
+	for _, collection := range collections {
+		methodNames := methodNamesByCollection[collection]
+
+		for _, property := range collection.BackRelations {
+			name := property.CollectionGoName + "Via" + property.GoName
+			backRelationName := name
+
+			for i := 1; methodNames[backRelationName] != ""; i++ {
+				reason := fmt.Sprintf("method %s collides with %s", backRelationName, methodNames[backRelationName])
+				if i == 1 {
+					backRelationName = name + suffix
+				} else {
+					backRelationName = fmt.Sprintf("%s%s%d", name, suffix, i)
+				}
+
+				renames = append(renames, IdentifierRename{Collection: collection.Collection.Name, Field: property.CollectionName + "." + property.Name, From: name, To: backRelationName, Reason: reason})
+			}
+
+			methodNames[backRelationName] = fmt.Sprintf("back relation of %s.%s", property.CollectionName, property.Name)
+			property.BackRelationName = backRelationName
+		}
+	}
+
+	return renames
+}
+
+// sanitizeIdentifier makes sure name is a valid exported go identifier
+func sanitizeIdentifier(name string) (string, string) {
+	reason := ""
+
+	sanitized := strings.Map(func(r rune) rune {
+		if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+
+		reason = "contains invalid characters"
+		return -1
+	}, name)
+
+	if sanitized == "" || !unicode.IsUpper([]rune(sanitized)[0]) {
+		sanitized = "X" + sanitized
+		reason = "no valid exported identifier"
+	}
+
+	return sanitized, reason
+}
+
+// uniqueIdentifier appends suffix and a counter to name until it is not part of used, the result is added to used
+func uniqueIdentifier(name string, suffix string, used map[string]bool) string {
+	output := name
+
+	for i := 1; used[output]; i++ {
+		if i == 1 && suffix != "" {
+			output = name + suffix
+		} else {
+			output = fmt.Sprintf("%s%s%d", name, suffix, i)
+		}
+	}
+
+	used[output] = true
+
+	return output
+}
+
+func resolveEnumValueNames(values []string) []string {
+	output := make([]string, len(values))
+	used := map[string]bool{}
+
+	for i, value := range values {
+		name := strings.Map(func(r rune) rune {
+			if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return r
+			}
+
+			return -1
+		}, strcase.ToCamel(value))
+
+		if name == "" {
+			name = "Empty"
+		}
+
+		output[i] = uniqueIdentifier(name, "", used)
+	}
+
+	return output
+}
+
+func containsString(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
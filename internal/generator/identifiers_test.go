@@ -0,0 +1,227 @@
+package generator
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"slices"
+	"testing"
+
+	"github.com/arturh85/pocketbase-go-generator/internal/cmd"
+	"github.com/arturh85/pocketbase-go-generator/internal/pocketbase_api"
+)
+
+func newTestCollection(name string, collectionType string, properties ...*InterfaceProperty) *CollectionWithProperties {
+	for _, property := range properties {
+		property.CollectionName = name
+		if property.FieldType == "" {
+			property.FieldType = "text"
+		}
+	}
+
+	return &CollectionWithProperties{
+		Collection: &pocketbase_api.Collection{Name: name, Type: collectionType},
+		Properties: properties,
+	}
+}
+
+func TestResolveIdentifiers(t *testing.T) {
+	users := newTestCollection("users", "auth",
+		&InterfaceProperty{Name: "email", CoreAccessors: true},
+		&InterfaceProperty{Name: "posts_via_author"},
+	)
+	author := &InterfaceProperty{Name: "author", Type: IptRelation, FieldType: "relation", RelationTarget: users}
+	users.BackRelations = []*InterfaceProperty{author}
+
+	posts := newTestCollection("posts", "base",
+		&InterfaceProperty{Name: "2fa"},
+		&InterfaceProperty{Name: "foo_bar"},
+		&InterfaceProperty{Name: "fooBar"},
+		&InterfaceProperty{Name: "foo-bar"},
+		&InterfaceProperty{Name: "expand"},
+		&InterfaceProperty{Name: "collection"},
+		&InterfaceProperty{Name: "json"},
+		&InterfaceProperty{Name: "type"},
+		&InterfaceProperty{Name: "title"},
+		&InterfaceProperty{Name: "titleChanged"},
+		author,
+	)
+
+	collections := []*CollectionWithProperties{
+		users,
+		posts,
+		newTestCollection("foo_bar", "base"),
+		newTestCollection("fooBar", "base"),
+	}
+
+	renames := ResolveIdentifiers(collections, "")
+
+	propertyTests := []struct {
+		name       string
+		property   *InterfaceProperty
+		goName     string
+		methodName string
+		paramName  string
+	}{
+		{"leading digit", posts.Properties[0], "X2Fa", "X2Fa", "x2Fa"},
+		{"first of colliding names", posts.Properties[1], "FooBar", "FooBar", "fooBar"},
+		{"second of colliding names", posts.Properties[2], "FooBarField", "FooBarField", "fooBarField"},
+		{"suffix numbering", posts.Properties[3], "FooBarField2", "FooBarField2", "fooBarField2"},
+		{"generated struct field", posts.Properties[4], "ExpandField", "ExpandField", "expandField"},
+		{"core.Record method", posts.Properties[5], "Collection", "CollectionField", "collection"},
+		{"reserved param name", posts.Properties[6], "Json", "Json", "jsonField"},
+		{"keyword param name", posts.Properties[7], "Type", "Type", "typeField"},
+		{"method of other field", posts.Properties[9], "TitleChanged", "TitleChangedField", "titleChanged"},
+		{"core accessors are not renamed", users.Properties[0], "Email", "Email", "email"},
+	}
+
+	for _, test := range propertyTests {
+		if test.property.GoName != test.goName || test.property.MethodName != test.methodName || test.property.ParamName != test.paramName {
+			t.Errorf("%s: expected %s, %s, %s, got %s, %s, %s", test.name,
+				test.goName, test.methodName, test.paramName,
+				test.property.GoName, test.property.MethodName, test.property.ParamName)
+		}
+	}
+
+	collectionTests := []struct {
+		collection *CollectionWithProperties
+		goName     string
+	}{
+		{collections[2], "FooBar"},
+		{collections[3], "FooBar1"},
+	}
+
+	for _, test := range collectionTests {
+		if test.collection.GoName != test.goName {
+			t.Errorf("collection %s: expected %s, got %s", test.collection.Collection.Name, test.goName, test.collection.GoName)
+		}
+	}
+
+	if author.BackRelationName != "PostsViaAuthorField" {
+		t.Errorf("expected back relation to be renamed to PostsViaAuthorField, got %s", author.BackRelationName)
+	}
+
+	expectedRenames := []string{
+		"posts.2fa: renamed 2Fa to X2Fa (no valid exported identifier)",
+		"posts.fooBar: renamed FooBar to FooBarField (collides with another field)",
+		"posts.foo-bar: renamed FooBar to FooBarField2 (collides with another field)",
+		"posts.expand: renamed Expand to ExpandField (collides with another field)",
+		"posts.collection: renamed Collection to CollectionField (method Collection collides with core.Record method)",
+		"posts.titleChanged: renamed TitleChanged to TitleChangedField (method TitleChanged collides with method of field title)",
+		"users.posts.author: renamed PostsViaAuthor to PostsViaAuthorField (method PostsViaAuthor collides with method of field posts_via_author)",
+	}
+
+	messages := make([]string, len(renames))
+	for i, rename := range renames {
+		messages[i] = rename.String()
+	}
+
+	for _, expected := range expectedRenames {
+		if !slices.Contains(messages, expected) {
+			t.Errorf("expected rename %q in %q", expected, messages)
+		}
+	}
+}
+
+func TestResolveCollectionDeclarations(t *testing.T) {
+	collections := []*CollectionWithProperties{
+		newTestCollection("auth", "base"),
+		newTestCollection("posts", "base"),
+		newTestCollection("fake_posts", "base"),
+		newTestCollection("fake_posts_field", "base"),
+	}
+
+	renames := ResolveIdentifiers(collections, "")
+
+	tests := []struct {
+		collection *CollectionWithProperties
+		goName     string
+		rename     string
+	}{
+		{collections[0], "AuthField", "auth: renamed Auth to AuthField (AuthRecord collides with runtime declaration)"},
+		{collections[1], "Posts", ""},
+		{collections[2], "FakePostsField", "fake_posts: renamed FakePosts to FakePostsField (NewFakePostsRepo collides with declaration of collection posts)"},
+		{collections[3], "FakePostsField1", "fake_posts_field: renamed FakePostsField to FakePostsField1 (collides with another collection)"},
+	}
+
+	messages := make([]string, len(renames))
+	for i, rename := range renames {
+		messages[i] = rename.String()
+	}
+
+	for _, test := range tests {
+		if test.collection.GoName != test.goName {
+			t.Errorf("collection %s: expected %s, got %s", test.collection.Collection.Name, test.goName, test.collection.GoName)
+		}
+
+		if test.rename != "" && !slices.Contains(messages, test.rename) {
+			t.Errorf("expected rename %q in %q", test.rename, messages)
+		}
+	}
+}
+
+// TestRuntimeNames makes sure every exported runtime declaration is reserved
+func TestRuntimeNames(t *testing.T) {
+	schemaSnapshot, err := GetGoSchemaSnapshot(nil, &cmd.GeneratorFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sources := []string{
+		GetGoFilterRuntime(), GetGoListRuntime(), GetGoFakeRuntime(), GetGoPersistenceRuntime(), GetGoFilesRuntime(),
+		GetGoAuthRuntime(), GetGoValidationRuntime(), GetGoConvertRuntime(), GetGoChangesRuntime(), GetGoSchemaRuntime(),
+		GetGoVerifySchema(nil, &cmd.GeneratorFlags{}), schemaSnapshot,
+	}
+
+	for _, source := range sources {
+		file, err := parser.ParseFile(token.NewFileSet(), "", "package collections\n"+source, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var names []string
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Recv == nil {
+					names = append(names, decl.Name.Name)
+				}
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						names = append(names, spec.Name.Name)
+					case *ast.ValueSpec:
+						for _, name := range spec.Names {
+							names = append(names, name.Name)
+						}
+					}
+				}
+			}
+		}
+
+		for _, name := range names {
+			if ast.IsExported(name) && !slices.Contains(runtimeNames, name) {
+				t.Errorf("runtime declaration %s is missing in runtimeNames", name)
+			}
+		}
+	}
+}
+
+func TestResolveEnumValueNames(t *testing.T) {
+	tests := []struct {
+		values   []string
+		expected []string
+	}{
+		{[]string{"draft", "published"}, []string{"Draft", "Published"}},
+		{[]string{"a-b", "a_b", "a b"}, []string{"AB", "AB1", "AB2"}},
+		{[]string{"", "!"}, []string{"Empty", "Empty1"}},
+		{[]string{"1st", "2nd"}, []string{"1St", "2Nd"}},
+	}
+
+	for _, test := range tests {
+		if names := resolveEnumValueNames(test.values); !slices.Equal(names, test.expected) {
+			t.Errorf("%q: expected %q, got %q", test.values, test.expected, names)
+		}
+	}
+}
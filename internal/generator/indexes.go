@@ -0,0 +1,89 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arturh85/pocketbase-go-generator/internal/cmd"
+)
+
+// CollectionIndex is a parsed index of a collection whose columns are all generated properties
+type CollectionIndex struct {
+	Name       string
+	Unique     bool
+	Properties []*InterfaceProperty
+}
+
+/*
+example finders:
+
+	func Users_FindByEmail(app core.App, email string) (*UsersRecord, error) {
+	    ...
+	}
+
+	func Posts_FindAllByAuthorAndStatus(app core.App, author string, status string) ([]*PostsRecord, error) {
+	    ...
+	}
+*/
+func (collection CollectionWithProperties) GetGoIndexFinders(generatorFlags *cmd.GeneratorFlags) string {
+	var finders []string
+	generated := map[string]bool{}
+
+	for _, index := range collection.Indexes {
+		names := make([]string, len(index.Properties))
+		params := make([]string, len(index.Properties))
+		conditions := make([]string, len(index.Properties))
+		values := make([]string, len(index.Properties))
+
+		for i, property := range index.Properties {
+			names[i] = property.GoName
+			params[i] = fmt.Sprintf("%s %s", property.ParamName, property.getGoRecordType(propertyFlags{relationAsString: true}))
+			conditions[i] = fmt.Sprintf("%s = {:p%d}", property.Name, i)
+			values[i] = fmt.Sprintf("\"p%d\": %s", i, property.ParamName)
+		}
+
+		finderName := "FindBy" + strings.Join(names, "And")
+		if !index.Unique {
+			finderName = "FindAllBy" + strings.Join(names, "And")
+		}
+
+		if generated[finderName] {
+			continue
+		}
+		generated[finderName] = true
+
+		if index.Unique {
+			finders = append(finders, fmt.Sprintf(`
+// %[1]s_%[2]s returns the record matching the unique index %[3]s
+func %[1]s_%[2]s(app core.App, %[4]s) (*%[1]sRecord, error) {
+	_record, err := app.FindFirstRecordByFilter(Collection%[1]s, "%[5]s", dbx.Params{%[6]s})
+	if err != nil { return nil, err }
+	return %[1]s_Wrap(_record), nil
+}
+`,
+				collection.GoName,
+				finderName,
+				index.Name,
+				strings.Join(params, ", "),
+				strings.Join(conditions, " && "),
+				strings.Join(values, ", "),
+			))
+		} else {
+			finders = append(finders, fmt.Sprintf(`
+// %[1]s_%[2]s returns all records matching the index %[3]s
+func %[1]s_%[2]s(app core.App, %[4]s) ([]*%[1]sRecord, error) {
+	return %[1]s_FindRecordsByFilter(app, "%[5]s", "", 0, 0, dbx.Params{%[6]s})
+}
+`,
+				collection.GoName,
+				finderName,
+				index.Name,
+				strings.Join(params, ", "),
+				strings.Join(conditions, " && "),
+				strings.Join(values, ", "),
+			))
+		}
+	}
+
+	return strings.Join(finders, "")
+}
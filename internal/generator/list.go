@@ -0,0 +1,182 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arturh85/pocketbase-go-generator/internal/cmd"
+)
+
+// listRuntime is emitted once per generated file and contains the types used by all XxxSort variables and Xxx_List
+const listRuntime = `
+// DefaultPerPage is used by the generated Xxx_List functions if ListOptions.PerPage is not set
+const DefaultPerPage = 30
+
+// SortField is a typed sort key, use the generated XxxSort variables to create one
+type SortField struct{ name string }
+
+func (f SortField) Asc() SortOrder  { return SortOrder(f.name) }
+func (f SortField) Desc() SortOrder { return SortOrder("-" + f.name) }
+
+// SortOrder is a single pocketbase sort expression like "-created"
+type SortOrder string
+
+// ListOptions configures the generated Xxx_List functions, Page starts with 1
+type ListOptions struct {
+	Filter  Filter
+	Sort    []SortOrder
+	Page    int
+	PerPage int
+}
+
+func (opts ListOptions) pagination() (int, int) {
+	page, perPage := opts.Page, opts.PerPage
+	if page < 1 { page = 1 }
+	if perPage < 1 { perPage = DefaultPerPage }
+	return page, perPage
+}
+
+func (opts ListOptions) sort() string {
+	parts := make([]string, len(opts.Sort))
+	for i, order := range opts.Sort { parts[i] = string(order) }
+	return strings.Join(parts, ",")
+}
+
+// ListResult is one page of records returned by the generated Xxx_List functions
+type ListResult[T any] struct {
+	Items      []T
+	Page       int
+	PerPage    int
+	TotalItems int
+	TotalPages int
+}
+
+func newListResult[T any](items []T, page int, perPage int, totalItems int64) ListResult[T] {
+	return ListResult[T]{
+		Items:      items,
+		Page:       page,
+		PerPage:    perPage,
+		TotalItems: int(totalItems),
+		TotalPages: int((totalItems + int64(perPage) - 1) / int64(perPage)),
+	}
+}
+
+// countRecordsByFilter counts the records matching filter, resolving relations and multiple value fields the same
+// way app.FindRecordsByFilter does
+func countRecordsByFilter(app core.App, collectionName string, filter string, params dbx.Params) (int64, error) {
+	if filter == "" {
+		return app.CountRecords(collectionName)
+	}
+
+	collection, err := app.FindCachedCollectionByNameOrId(collectionName)
+	if err != nil { return 0, err }
+
+	resolver := core.NewRecordFieldResolver(app, collection, nil, true)
+	expr, err := search.FilterData(filter).BuildExpr(resolver, params)
+	if err != nil { return 0, fmt.Errorf("invalid filter expression: %w", err) }
+
+	query := app.RecordQuery(collection).Select(collection.Name + ".id").AndWhere(expr)
+	resolver.UpdateQuery(query)
+	subQuery := query.Build()
+
+	return app.CountRecords(collection, dbx.NewExp("[[id]] IN ("+subQuery.SQL()+")", subQuery.Params()))
+}
+
+// AllBatchSize is the number of records loaded per query by the generated Xxx_All functions
+var AllBatchSize = 500
+
+// allRecords iterates over all records matching filter ordered by id, loading them in batches of AllBatchSize.
+// Batches continue after the last seen id instead of using an offset, so late pages are as fast as the first one.
+func allRecords(app core.App, collectionName string, filter string, params ...dbx.Params) iter.Seq2[*core.Record, error] {
+	return func(yield func(*core.Record, error) bool) {
+		batchFilter := "id > {:allLastId}"
+		if filter != "" { batchFilter = "(" + filter + ") && " + batchFilter }
+
+		batchParams := dbx.Params{}
+		for _, p := range params {
+			for k, v := range p { batchParams[k] = v }
+		}
+		batchParams["allLastId"] = ""
+
+		for {
+			records, err := app.FindRecordsByFilter(collectionName, batchFilter, "id", AllBatchSize, 0, batchParams)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for _, record := range records {
+				if !yield(record, nil) { return }
+			}
+
+			if len(records) < AllBatchSize { return }
+			batchParams["allLastId"] = records[len(records)-1].Id
+		}
+	}
+}
+`
+
+func GetGoListRuntime() string {
+	return listRuntime
+}
+
+/*
+example sort:
+
+	var PostsSort = struct {
+	    Title SortField
+	}{
+	    Title: SortField{name: "title"},
+	}
+*/
+func (collection CollectionWithProperties) GetGoSort(generatorFlags *cmd.GeneratorFlags) string {
+	var fieldTypes []string
+	var fieldValues []string
+
+	for _, property := range collection.Properties {
+		if property.IsArray || property.Type == IptJson {
+			continue
+		}
+
+		fieldTypes = append(fieldTypes, fmt.Sprintf("    %s SortField", property.GoName))
+		fieldValues = append(fieldValues, fmt.Sprintf("    %s: SortField{name: \"%s\"},", property.GoName, property.Name))
+	}
+
+	template := `
+func $$$_List(app core.App, opts ListOptions) (ListResult[*$$$Record], error) {
+	page, perPage := opts.pagination()
+	expr, params := opts.Filter.Build()
+	total, err := countRecordsByFilter(app, Collection$$$, expr, params)
+	if err != nil { return ListResult[*$$$Record]{}, err }
+	records, err := $$$_FindRecordsByFilter(app, expr, opts.sort(), perPage, (page-1)*perPage, params)
+	if err != nil { return ListResult[*$$$Record]{}, err }
+	return newListResult(records, page, perPage, total), nil
+}
+`
+
+	return fmt.Sprintf("var %sSort = struct {\n%s\n}{\n%s\n}\n%s",
+		collection.GoName,
+		strings.Join(fieldTypes, "\n"),
+		strings.Join(fieldValues, "\n"),
+		strings.ReplaceAll(template, "$$$", collection.GoName),
+	)
+}
+
+func (collection CollectionWithProperties) GetGoAll(generatorFlags *cmd.GeneratorFlags) string {
+	template := `
+// $$$_All iterates over all records matching filter without loading them at once, see AllBatchSize
+func $$$_All(app core.App, filter string, params ...dbx.Params) iter.Seq2[*$$$Record, error] {
+	return func(yield func(*$$$Record, error) bool) {
+		for _record, err := range allRecords(app, Collection$$$, filter, params...) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield($$$_Wrap(_record), nil) { return }
+		}
+	}
+}
+`
+
+	return strings.ReplaceAll(template, "$$$", collection.GoName)
+}
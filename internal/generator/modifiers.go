@@ -0,0 +1,61 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/arturh85/pocketbase-go-generator/internal/cmd"
+)
+
+// hasGoRecordModifiers reports whether Add, Remove and Prepend are generated for the property, file fields get
+// their own helpers as their modifiers take uploaded files
+func (property InterfaceProperty) hasGoRecordModifiers() bool {
+	return property.IsArray && (property.Type == IptRelation || property.Type == IptEnum)
+}
+
+/*
+example modifiers:
+
+	func (a *PostsRecord) AddTags(values ...string) {
+	    a.Set("tags+", values)
+	}
+
+	func (a *PostsRecord) IncrementViews(n float64) {
+	    a.Set("views+", n)
+	}
+*/
+func (property InterfaceProperty) GetGoRecordModifiers(generatorFlags *cmd.GeneratorFlags, flags propertyFlags) string {
+	if property.Type == IptNumber {
+		return fmt.Sprintf(`
+// Increment%[2]s adds n to %[3]s, use a negative n to decrement
+func (a *%[1]sRecord) Increment%[2]s(n float64) {
+	a.Set("%[3]s+", n)
+}
+`, property.CollectionGoName, property.MethodName, property.getGoName(generatorFlags, flags))
+	}
+
+	if !property.hasGoRecordModifiers() {
+		return ""
+	}
+
+	paramName := "values"
+	if property.Type == IptRelation {
+		paramName = "ids"
+	}
+
+	return fmt.Sprintf(`
+// Add%[2]s appends %[4]s to %[3]s
+func (a *%[1]sRecord) Add%[2]s(%[4]s ...string) {
+	a.Set("%[3]s+", %[4]s)
+}
+
+// Prepend%[2]s inserts %[4]s at the start of %[3]s
+func (a *%[1]sRecord) Prepend%[2]s(%[4]s ...string) {
+	a.Set("+%[3]s", %[4]s)
+}
+
+// Remove%[2]s removes %[4]s from %[3]s
+func (a *%[1]sRecord) Remove%[2]s(%[4]s ...string) {
+	a.Set("%[3]s-", %[4]s)
+}
+`, property.CollectionGoName, property.MethodName, property.getGoName(generatorFlags, flags), paramName)
+}
@@ -0,0 +1,92 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/arturh85/pocketbase-go-generator/internal/cmd"
+)
+
+// persistenceRuntime is emitted once per generated file and contains the error type returned by the generated
+// Save, Delete and Validate methods
+const persistenceRuntime = `
+// ValidationError is returned by the generated Save, Validate and XxxStruct.Validate methods if the record is invalid
+type ValidationError struct {
+	Collection string
+	// Fields maps the pocketbase field names, which are the values of the XxxFields variables, to their validation
+	// error. The errors of nested validation.Errors, like those of hooks validating json fields, are also added with
+	// their joined path like "meta.title". Errors not related to a single field use their pocketbase key.
+	Fields map[string]error
+
+	errs validation.Errors
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Collection, e.errs.Error())
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.errs
+}
+
+// Field returns the validation error of the field, nil if the field is valid
+func (e *ValidationError) Field(name string) error {
+	return e.Fields[name]
+}
+
+// newRecordError converts validation.Errors returned by pocketbase to a *ValidationError, other errors are
+// returned as is
+func newRecordError(collectionName string, err error) error {
+	var errs validation.Errors
+	if !errors.As(err, &errs) {
+		return err
+	}
+
+	fields := make(map[string]error, len(errs))
+	addFieldErrors(fields, "", errs)
+
+	return &ValidationError{Collection: collectionName, Fields: fields, errs: errs}
+}
+
+// addFieldErrors adds errs to fields with their name prefixed by prefix, nested validation.Errors are added as well
+func addFieldErrors(fields map[string]error, prefix string, errs validation.Errors) {
+	for name, err := range errs {
+		fields[prefix+name] = err
+
+		var nested validation.Errors
+		if errors.As(err, &nested) { addFieldErrors(fields, prefix+name+".", nested) }
+	}
+}
+`
+
+func GetGoPersistenceRuntime() string {
+	return persistenceRuntime
+}
+
+func (collection CollectionWithProperties) GetGoPersistence(generatorFlags *cmd.GeneratorFlags) string {
+	template := `
+// Save validates and saves the record, validation errors are returned as *ValidationError
+func (a *$$$Record) Save(app core.App) error {
+	return newRecordError(Collection$$$, app.Save(a))
+}
+
+// Delete deletes the record together with the records referencing it with cascade delete. It fails if the record is
+// still referenced by a required relation without cascade delete, validation errors of hooks are returned as
+// *ValidationError.
+func (a *$$$Record) Delete(app core.App) error {
+	return newRecordError(Collection$$$, app.Delete(a))
+}
+
+// Validate validates the record without saving it, validation errors are returned as *ValidationError
+func (a *$$$Record) Validate(app core.App) error {
+	return newRecordError(Collection$$$, app.Validate(a))
+}
+
+// $$$_RunInTransaction runs fn in a database transaction which is rolled back if fn returns an error. Use tx
+// instead of app for all generated helpers called in fn.
+func $$$_RunInTransaction(app core.App, fn func(tx core.App) error) error {
+	return app.RunInTransaction(fn)
+}
+`
+
+	return strings.ReplaceAll(template, "$$$", collection.GoName)
+}
@@ -2,11 +2,11 @@ package generator
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/arturh85/pocketbase-go-generator/internal/cmd"
 	"github.com/arturh85/pocketbase-go-generator/internal/pocketbase_api"
-	"github.com/iancoleman/strcase"
 )
 
 type InterfacePropertyType int
@@ -29,11 +29,86 @@ type InterfaceProperty struct {
 	Type           InterfacePropertyType
 	IsArray        bool
 	Data           interface{}
+
+	// FieldType is the pocketbase field type like "text" or "autodate", Type groups several of them
+	FieldType string
+	// MaxSelect is the maximum number of values of select, relation and file fields
+	MaxSelect int
+	// Thumbs are the thumb sizes of file fields like "100x100"
+	Thumbs []string
+	// MimeTypes are the mime types accepted by file fields, empty if all are accepted
+	MimeTypes []string
+	// System fields can not be renamed or deleted in the dashboard, they are not necessarily read-only
+	System bool
+	// PrimaryKey is set for the id field
+	PrimaryKey bool
+	// OnCreate and OnUpdate are set for autodate fields which pocketbase sets when a record is created or updated
+	OnCreate bool
+	OnUpdate bool
+	// Constraints are the validation options of the field
+	Constraints FieldConstraints
+	// CoreAccessors is set for fields whose getter and setter are inherited from core.Record, like the email of auth
+	// collections
+	CoreAccessors bool
+
+	// GoName is the identifier used for struct fields and field name constants
+	GoName string
+	// MethodName is the identifier used for getters and setters on the record, it differs from GoName when
+	// it would collide with methods of core.Record
+	MethodName string
+	// ParamName is the identifier used for setter parameters
+	ParamName string
+	// CollectionGoName is the identifier of the collection this property belongs to
+	CollectionGoName string
+	// EnumValueNames are the identifiers of the enum constants, in the same order as Data
+	EnumValueNames []string
+
+	// RelationTarget is the interpreted collection a relation points to, nil if it is not generated
+	RelationTarget *CollectionWithProperties
+	// RecursiveRelation is set for relations which would embed a struct into itself and must use a pointer
+	RecursiveRelation bool
+	// BackRelationName is the method generated on the RelationTarget record to find records referencing it
+	BackRelationName string
+}
+
+// FieldConstraints are the validation options of a field, they have the same meaning as the options of the
+// pocketbase field types
+type FieldConstraints struct {
+	// Min and Max are the length of text fields and the value of number fields, nil if not set
+	Min *float64
+	Max *float64
+	// Pattern is the regular expression text fields have to match
+	Pattern string
+	OnlyInt bool
+	// MinSelect is the minimum number of relations
+	MinSelect int
+	// MaxSize is the maximum size of files and of editor and json fields in bytes
+	MaxSize       int64
+	ExceptDomains []string
+	OnlyDomains   []string
 }
 
 type CollectionWithProperties struct {
 	Collection *pocketbase_api.Collection
 	Properties []*InterfaceProperty
+
+	// GoName is the identifier prefix of all types and functions generated for this collection
+	GoName string
+	// BackRelations are the relation properties of generated collections pointing to this collection
+	BackRelations []*InterfaceProperty
+	// Indexes are the indexes usable for finders, indexes on hidden, multiple value or json fields are skipped
+	Indexes []*CollectionIndex
+}
+
+// IsReadOnly reports whether the value of the property is set by pocketbase and can not be changed, this is the case
+// for the primary key and autodate fields
+func (property InterfaceProperty) IsReadOnly() bool {
+	return property.PrimaryKey || property.FieldType == "autodate"
+}
+
+// IsView reports whether the collection is a read-only view collection
+func (collection CollectionWithProperties) IsView() bool {
+	return collection.Collection.Type == "view"
 }
 
 type propertyFlags struct {
@@ -118,7 +193,7 @@ func (property InterfaceProperty) String() string {
 
 func (property InterfaceProperty) GetGoProperty(generatorFlags *cmd.GeneratorFlags, flags propertyFlags) string {
 	// IntValue int `json:"intValue"`
-	return fmt.Sprintf("%s %s `json:\"%s\"`", strcase.ToCamel(property.getGoName(generatorFlags, flags)), property.getGoTypeWithArray(flags), property.getGoName(generatorFlags, flags))
+	return fmt.Sprintf("%s %s `json:\"%s\"`", property.GoName, property.getGoTypeWithArray(flags), property.getGoName(generatorFlags, flags))
 }
 
 /*
@@ -129,12 +204,16 @@ example getter:
 	}
 */
 func (property InterfaceProperty) GetGoRecordGetter(generatorFlags *cmd.GeneratorFlags, flags propertyFlags) string {
+	if property.CoreAccessors {
+		return ""
+	}
+
 	intGetter := ""
 
 	if property.Type == IptNumber {
 		intGetter = fmt.Sprintf("func (a *%sRecord) %s() %s {\n    return a.%s(\"%s\")\n}\n\n",
-			strcase.ToCamel(property.CollectionName),
-			strcase.ToCamel(property.Name)+"Int",
+			property.CollectionGoName,
+			property.MethodName+"Int",
 			"int",
 			"GetInt",
 			property.getGoName(generatorFlags, flags),
@@ -143,8 +222,8 @@ func (property InterfaceProperty) GetGoRecordGetter(generatorFlags *cmd.Generato
 
 	return fmt.Sprintf("%sfunc (a *%sRecord) %s() %s {\n    return a.%s(\"%s\")\n}\n",
 		intGetter,
-		strcase.ToCamel(property.CollectionName),
-		strcase.ToCamel(property.Name),
+		property.CollectionGoName,
+		property.MethodName,
 		property.getGoRecordType(flags),
 		property.getPocketbaseGetter(flags),
 		property.getGoName(generatorFlags, flags),
@@ -159,30 +238,52 @@ func (property InterfaceProperty) GetGoRecordExpandRelation(generatorFlags *cmd.
 	// }
 	// job := collections.Jobs_Wrap(wjob.ExpandedOne(collections.WorkerJobsFields.Job))
 
-	relationName := property.Data.(string)
-	return fmt.Sprintf(`		
-		func (a *%sRecord) Expand%s(app core.App) (%s, error) {
-			if errs := app.ExpandRecord(a.Record, []string{"%s"}, nil); len(errs) > 0 {
-				return nil, errs["%s"]
-			}
-			record := a.ExpandedOne("%s")
-			if record == nil { return nil, nil }
-			return %s_Wrap(record), nil
-		}
-		`,
-		strcase.ToCamel(property.CollectionName),
-		strcase.ToCamel(property.Name),
-		"*"+strcase.ToCamel(relationName)+"Record",
+	if property.IsArray {
+		return fmt.Sprintf(`
+func (a *%[1]sRecord) Expand%[2]s(app core.App) ([]*%[3]sRecord, error) {
+	if errs := app.ExpandRecord(a.Record, []string{"%[4]s"}, nil); len(errs) > 0 {
+		return nil, errs["%[4]s"]
+	}
+	return a.Expanded%[2]s(), nil
+}
 
+// Expanded%[2]s returns the already expanded %[4]s relation without querying the database
+func (a *%[1]sRecord) Expanded%[2]s() []*%[3]sRecord {
+	_records := a.ExpandedAll("%[4]s")
+	records := make([]*%[3]sRecord, len(_records))
+	for i, _record := range _records { records[i] = %[3]s_Wrap(_record) }
+	return records
+}
+`,
+			property.CollectionGoName,
+			property.MethodName,
+			property.RelationTarget.GoName,
+			property.getGoName(generatorFlags, flags),
+		)
+	}
+
+	return fmt.Sprintf(`
+func (a *%[1]sRecord) Expand%[2]s(app core.App) (*%[3]sRecord, error) {
+	if errs := app.ExpandRecord(a.Record, []string{"%[4]s"}, nil); len(errs) > 0 {
+		return nil, errs["%[4]s"]
+	}
+	return a.Expanded%[2]s(), nil
+}
+
+// Expanded%[2]s returns the already expanded %[4]s relation without querying the database
+func (a *%[1]sRecord) Expanded%[2]s() *%[3]sRecord {
+	record := a.ExpandedOne("%[4]s")
+	if record == nil { return nil }
+	return %[3]s_Wrap(record)
+}
+`,
+		property.CollectionGoName,
+		property.MethodName,
+		property.RelationTarget.GoName,
 		property.getGoName(generatorFlags, flags),
-		property.getGoName(generatorFlags, flags),
-		property.getGoName(generatorFlags, flags),
-		strcase.ToCamel(relationName),
 	)
 }
 
-var reserved = []string{"type", "var", "func", "map", "struct"}
-
 /*
 example setter:
 
@@ -192,25 +293,17 @@ example setter:
 */
 
 func (property InterfaceProperty) GetGoRecordSetter(generatorFlags *cmd.GeneratorFlags, flags propertyFlags) string {
-	var validGoName = strcase.ToLowerCamel(property.getGoName(generatorFlags, flags))
-	for _, word := range reserved {
-		if validGoName == word {
-			validGoName = "_" + validGoName
-			break
-		}
-	}
-
-	if property.Name == "created" || property.Name == "updated" {
+	if property.IsReadOnly() || property.CoreAccessors {
 		return ""
 	}
 
 	return fmt.Sprintf("func (a *%sRecord) Set%s(%s %s) {\n    a.Set(\"%s\", %s)\n}\n",
-		strcase.ToCamel(property.CollectionName),
-		strcase.ToCamel(property.Name),
-		validGoName,
+		property.CollectionGoName,
+		property.MethodName,
+		property.ParamName,
 		property.getGoRecordType(flags),
 		property.getGoName(generatorFlags, flags),
-		validGoName,
+		property.ParamName,
 	)
 }
 
@@ -235,20 +328,19 @@ func (property InterfaceProperty) getGoType(flags propertyFlags) string {
 			return "map[string]interface{}"
 		}
 	case IptEnum:
-		return strcase.ToCamel(fmt.Sprintf("%s_%s_%s", property.CollectionName, property.Name, "options"))
+		return property.getGoEnumName()
 	case IptRelation:
 		if flags.relationAsString {
 			return "string"
 		}
 
-		relationTo, ok := property.Data.(string)
-		if !ok {
+		if property.RelationTarget == nil {
 			return "map[string]interface{}"
 		} else {
-			if property.Optional {
-				return "*" + strcase.ToCamel(relationTo) + "Struct"
+			if property.Optional || property.RecursiveRelation {
+				return "*" + property.RelationTarget.GoName + "Struct"
 			} else {
-				return strcase.ToCamel(relationTo) + "Struct"
+				return property.RelationTarget.GoName + "Struct"
 			}
 		}
 	default:
@@ -328,7 +420,7 @@ func (property InterfaceProperty) getGoName(generatorFlags *cmd.GeneratorFlags,
 }
 
 func (collection CollectionWithProperties) GetGoCollectionEntry(generatorFlags *cmd.GeneratorFlags) string {
-	return fmt.Sprintf("    Collection%s = \"%s\"", strcase.ToCamel(collection.Collection.Name), collection.Collection.Name)
+	return fmt.Sprintf("    Collection%s = \"%s\"", collection.GoName, collection.Collection.Name)
 }
 
 func (collection CollectionWithProperties) GetGoCollectionHelperFuncs(generatorFlags *cmd.GeneratorFlags) string {
@@ -339,12 +431,6 @@ func $$$_Wrap(record *core.Record) *$$$Record {
 	return typedRecord
 }
 
-func $$$_New(app core.App) (*$$$Record, error) {
-	c, err := app.FindCollectionByNameOrId(Collection$$$)
-	if err != nil { return nil, err }
-	return $$$_Wrap(core.NewRecord(c)), nil
-}
-
 func $$$_FindRecordById(app core.App, recordId string, optFilters ...func(q *dbx.SelectQuery) error) (*$$$Record, error) {
 	_record, err := app.FindRecordById(Collection$$$, recordId, optFilters...)
 	if err != nil { return nil, err }
@@ -370,23 +456,60 @@ func $$$_FindRecordsByFilter(app core.App,
 	return records, err
 }
 	`
-	return strings.ReplaceAll(template, "$$$", strcase.ToCamel(collection.Collection.Name))
+
+	return strings.ReplaceAll(template, "$$$", collection.GoName)
+}
+
+func (collection CollectionWithProperties) GetGoCollectionConstructor(generatorFlags *cmd.GeneratorFlags) string {
+	return strings.ReplaceAll(`
+func $$$_New(app core.App) (*$$$Record, error) {
+	c, err := app.FindCollectionByNameOrId(Collection$$$)
+	if err != nil { return nil, err }
+	return $$$_Wrap(core.NewRecord(c)), nil
+}
+`, "$$$", collection.GoName)
+}
+
+// getGoViewComment returns the doc comment of view records showing their query, empty for other collections
+func (collection CollectionWithProperties) getGoViewComment() string {
+	if !collection.IsView() {
+		return ""
+	}
+
+	lines := []string{fmt.Sprintf("// %sRecord is a read-only record of the %s view collection:", collection.GoName, collection.Collection.Name), "//"}
+	for _, line := range strings.Split(strings.TrimSpace(collection.Collection.ViewQuery), "\n") {
+		lines = append(lines, "//\t"+strings.TrimRight(line, " \t\r"))
+	}
+
+	return strings.Join(lines, "\n") + "\n"
 }
 
 func (collection CollectionWithProperties) GetGoRecord(generatorFlags *cmd.GeneratorFlags) string {
 	properties := make([]string, len(collection.Properties))
 	var additionalTypes []string
 
+	recordFlags := propertyFlags{forceOptional: false, relationAsString: true}
+
 	for i, property := range collection.Properties {
-		properties[i] = fmt.Sprintf("%s\n\n%s",
-			property.GetGoRecordGetter(generatorFlags, propertyFlags{forceOptional: false, relationAsString: true}),
-			property.GetGoRecordSetter(generatorFlags, propertyFlags{forceOptional: false, relationAsString: true}))
+		properties[i] = property.GetGoRecordGetter(generatorFlags, recordFlags)
+
+		if property.Type == IptFile {
+			properties[i] += property.GetGoRecordFileHelpers(generatorFlags, recordFlags)
+		}
 
-		if property.Type == IptRelation {
-			properties[i] += property.GetGoRecordExpandRelation(generatorFlags, propertyFlags{forceOptional: false, relationAsString: true})
+		if property.Type == IptRelation && property.RelationTarget != nil {
+			properties[i] += property.GetGoRecordExpandRelation(generatorFlags, recordFlags)
 		}
 	}
 
+	for _, backRelation := range collection.BackRelations {
+		properties = append(properties, backRelation.GetGoRecordBackRelation(generatorFlags, propertyFlags{forceOptional: false, relationAsString: true}))
+	}
+
+	if backRelationExpands := collection.getGoBackRelationExpands(); backRelationExpands != "" {
+		additionalTypes = append(additionalTypes, backRelationExpands)
+	}
+
 	prefix := strings.Join(additionalTypes, "\n\n")
 
 	if prefix != "" {
@@ -394,6 +517,8 @@ func (collection CollectionWithProperties) GetGoRecord(generatorFlags *cmd.Gener
 	}
 
 	var publicExportStruct = `
+// PublicExportStruct converts the record with a json round trip of PublicExport, conversion errors result in
+// zero fields. Prefer ToStruct.
 func (a *$$$Record) PublicExportStruct() $$$Struct {
 	bytes, _ := json.Marshal(a.PublicExport())
 	var record = $$$Struct{}
@@ -402,15 +527,30 @@ func (a *$$$Record) PublicExportStruct() $$$Struct {
 }
 	`
 
-	return fmt.Sprintf("%s\nvar _ core.RecordProxy = (*%sRecord)(nil)\n\ntype %sRecord struct {\n    core.BaseRecordProxy\n}\n\n%s\n\n%s\n\n",
+	return fmt.Sprintf("%s\nvar _ core.RecordProxy = (*%sRecord)(nil)\n\n%stype %sRecord struct {\n    core.BaseRecordProxy\n}\n\n%s\n\n%s\n\n",
 		prefix,
-		strcase.ToCamel(collection.Collection.Name),
-		strcase.ToCamel(collection.Collection.Name),
+		collection.GoName,
+		collection.getGoViewComment(),
+		collection.GoName,
 		strings.Join(properties, "\n"),
-		strings.ReplaceAll(publicExportStruct, "$$$", strcase.ToCamel(collection.Collection.Name)),
+		strings.ReplaceAll(publicExportStruct, "$$$", collection.GoName),
 	)
 }
 
+// GetGoRecordSetters returns the setters and modifiers of XxxRecord
+func (collection CollectionWithProperties) GetGoRecordSetters(generatorFlags *cmd.GeneratorFlags) string {
+	flags := propertyFlags{forceOptional: false, relationAsString: true}
+
+	var setters []string
+	for _, property := range collection.Properties {
+		if setter := property.GetGoRecordSetter(generatorFlags, flags) + property.GetGoRecordModifiers(generatorFlags, flags); setter != "" {
+			setters = append(setters, setter)
+		}
+	}
+
+	return strings.Join(setters, "\n")
+}
+
 func (collection CollectionWithProperties) GetGoStruct(generatorFlags *cmd.GeneratorFlags) string {
 	properties := make([]string, len(collection.Properties))
 	var additionalTypes []string
@@ -419,16 +559,25 @@ func (collection CollectionWithProperties) GetGoStruct(generatorFlags *cmd.Gener
 	fieldNames := make([]string, len(collection.Properties))
 	fieldNameValues := make([]string, len(collection.Properties))
 
+	hasExpand := slices.ContainsFunc(collection.Properties, func(property *InterfaceProperty) bool {
+		return property.Type == IptRelation && property.RelationTarget != nil
+	})
+
 	for i, property := range collection.Properties {
-		fieldNames[i] = strcase.ToCamel(property.Name)
+		fieldNames[i] = property.GoName
 		fieldNameValues[i] = fmt.Sprintf("%s: \"%s\"", fieldNames[i], property.Name)
 		properties[i] = fmt.Sprintf("    %s;", property.GetGoProperty(generatorFlags, propertyFlags{forceOptional: false, relationAsString: true}))
 
+		// pocketbase exports the expanded relations as "expand", a field with the same name is left out of the json
+		if hasExpand && property.Name == "expand" {
+			properties[i] = fmt.Sprintf("    %s %s `json:\"-\"`;", property.GoName, property.getGoTypeWithArray(propertyFlags{relationAsString: true}))
+		}
+
 		if property.Type == IptEnum {
 			additionalTypes = append(additionalTypes, property.getGoEnum())
 		}
 
-		if property.Type == IptRelation {
+		if property.Type == IptRelation && property.RelationTarget != nil {
 			expandedRelations = append(expandedRelations, fmt.Sprintf("    %s;", property.GetGoProperty(generatorFlags, propertyFlags{forceOptional: true, relationAsString: false})))
 		}
 	}
@@ -436,11 +585,11 @@ func (collection CollectionWithProperties) GetGoStruct(generatorFlags *cmd.Gener
 	if len(expandedRelations) > 0 {
 		// expandedRelations = append(expandedRelations, "    [key: string]: unknown;")
 
-		expandedType := fmt.Sprintf("type %sExpanded struct {\n%s\n}", strcase.ToCamel(collection.Collection.Name), strings.Join(expandedRelations, "\n"))
+		expandedType := fmt.Sprintf("type %sExpanded struct {\n%s\n}", collection.GoName, strings.Join(expandedRelations, "\n"))
 
 		additionalTypes = append(additionalTypes, expandedType)
 
-		expandedLine := fmt.Sprintf("    Expand %sExpanded `json:\"expand\"`", strcase.ToCamel(collection.Collection.Name))
+		expandedLine := fmt.Sprintf("    Expand %sExpanded `json:\"expand\"`", collection.GoName)
 
 		properties = append([]string{expandedLine}, properties...)
 	} else {
@@ -455,8 +604,8 @@ func (collection CollectionWithProperties) GetGoStruct(generatorFlags *cmd.Gener
 		prefix += "\n\n"
 	}
 
-	var fieldsInfo = fmt.Sprintf("var %sFields = struct {\n    %s string\n}{\n%s,\n}", strcase.ToCamel(collection.Collection.Name), strings.Join(fieldNames, ", "), strings.Join(fieldNameValues, ",\n"))
-	return fmt.Sprintf("%stype %sStruct struct {\n%s\n}\n\n%s", prefix, strcase.ToCamel(collection.Collection.Name), strings.Join(properties, "\n"), fieldsInfo)
+	var fieldsInfo = fmt.Sprintf("var %sFields = struct {\n    %s string\n}{\n%s,\n}", collection.GoName, strings.Join(fieldNames, ", "), strings.Join(fieldNameValues, ",\n"))
+	return fmt.Sprintf("%stype %sStruct struct {\n%s\n}\n\n%s", prefix, collection.GoName, strings.Join(properties, "\n"), fieldsInfo)
 }
 
 func (property InterfaceProperty) getGoEnum() string {
@@ -465,13 +614,17 @@ func (property InterfaceProperty) getGoEnum() string {
 	}
 
 	enumData := property.Data.([]string)
-	enumName := strcase.ToCamel(fmt.Sprintf("%s_%s_%s", property.CollectionName, property.Name, "options"))
+	enumName := property.getGoEnumName()
 
 	enumList := make([]string, len(enumData))
 
 	for i, enum := range enumData {
-		enumList[i] = fmt.Sprintf("    %s %s = \"%s\"", enumName+"_"+strcase.ToCamel(enum), enumName, enum)
+		enumList[i] = fmt.Sprintf("    %s %s = %q", enumName+"_"+property.EnumValueNames[i], enumName, enum)
 	}
 
 	return fmt.Sprintf("type %s string\nconst (\n%s\n)", enumName, strings.Join(enumList, "\n"))
 }
+
+func (property InterfaceProperty) getGoEnumName() string {
+	return property.CollectionGoName + property.GoName + "Options"
+}
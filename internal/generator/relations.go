@@ -0,0 +1,56 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arturh85/pocketbase-go-generator/internal/cmd"
+)
+
+/*
+example back relation for posts.author -> users:
+
+	func (a *UsersRecord) PostsViaAuthor(app core.App, filter string, params ...dbx.Params) ([]*PostsRecord, error) {
+	    ...
+	}
+*/
+func (property InterfaceProperty) GetGoRecordBackRelation(generatorFlags *cmd.GeneratorFlags, flags propertyFlags) string {
+	condition := property.getGoName(generatorFlags, flags) + " ="
+	if property.IsArray {
+		condition = property.getGoName(generatorFlags, flags) + ":each ?="
+	}
+
+	return fmt.Sprintf(`
+// %[2]s returns all %[4]s records referencing this record in their %[5]s field, filter is optional
+func (a *%[1]sRecord) %[2]s(app core.App, filter string, params ...dbx.Params) ([]*%[3]sRecord, error) {
+	_filter := "%[6]s {:viaRecordId}"
+	if filter != "" { _filter += " && (" + filter + ")" }
+	_params := dbx.Params{"viaRecordId": a.Record.Id}
+	for _, p := range params {
+		for k, v := range p { _params[k] = v }
+	}
+	return %[3]s_FindRecordsByFilter(app, _filter, "", 0, 0, _params)
+}
+`,
+		property.RelationTarget.GoName,
+		property.BackRelationName,
+		property.CollectionGoName,
+		property.CollectionName,
+		property.getGoName(generatorFlags, flags),
+		condition,
+	)
+}
+
+func (collection CollectionWithProperties) getGoBackRelationExpands() string {
+	if len(collection.BackRelations) == 0 {
+		return ""
+	}
+
+	expands := make([]string, len(collection.BackRelations))
+
+	for i, property := range collection.BackRelations {
+		expands[i] = fmt.Sprintf("    %sExpand%s = \"%s_via_%s\"", collection.GoName, property.BackRelationName, property.CollectionName, property.Name)
+	}
+
+	return fmt.Sprintf("const (\n%s\n)", strings.Join(expands, "\n"))
+}
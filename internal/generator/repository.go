@@ -0,0 +1,71 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arturh85/pocketbase-go-generator/internal/cmd"
+	"github.com/iancoleman/strcase"
+)
+
+func (collection CollectionWithProperties) GetGoRepository(generatorFlags *cmd.GeneratorFlags) string {
+	template := `
+// $$$Repository bundles the data access to $$$Record, services can depend on it instead of core.App
+type $$$Repository interface {
+	Find(id string) (*$$$Record, error)
+	List(opts ListOptions) (ListResult[*$$$Record], error)
+	Count(filter Filter) (int64, error)
+	Exists(id string) (bool, error)
+%s}
+
+type ###Repo struct {
+	app core.App
+}
+
+var _ $$$Repository = (*###Repo)(nil)
+
+func New$$$Repo(app core.App) $$$Repository {
+	return &###Repo{app: app}
+}
+
+func (r *###Repo) Find(id string) (*$$$Record, error) {
+	return $$$_FindRecordById(r.app, id)
+}
+
+func (r *###Repo) List(opts ListOptions) (ListResult[*$$$Record], error) {
+	return $$$_List(r.app, opts)
+}
+
+func (r *###Repo) Count(filter Filter) (int64, error) {
+	expr, params := filter.Build()
+	return countRecordsByFilter(r.app, Collection$$$, expr, params)
+}
+
+func (r *###Repo) Exists(id string) (bool, error) {
+	total, err := countRecordsByFilter(r.app, Collection$$$, "id = {:id}", dbx.Params{"id": id})
+	return total > 0, err
+}
+`
+
+	// Save and Delete of views are missing in XxxRepository as GetGoRepositoryWriters is not generated for them
+	writeMethods := ""
+	if !collection.IsView() {
+		writeMethods = "	Save(record *$$$Record) error\n	Delete(record *$$$Record) error\n"
+	}
+
+	return strings.NewReplacer("$$$", collection.GoName, "###", strcase.ToLowerCamel(collection.GoName)).Replace(fmt.Sprintf(template, writeMethods))
+}
+
+func (collection CollectionWithProperties) GetGoRepositoryWriters(generatorFlags *cmd.GeneratorFlags) string {
+	template := `
+func (r *###Repo) Save(record *$$$Record) error {
+	return record.Save(r.app)
+}
+
+func (r *###Repo) Delete(record *$$$Record) error {
+	return record.Delete(r.app)
+}
+`
+
+	return strings.NewReplacer("$$$", collection.GoName, "###", strcase.ToLowerCamel(collection.GoName)).Replace(template)
+}
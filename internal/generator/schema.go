@@ -0,0 +1,151 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/arturh85/pocketbase-go-generator/internal/cmd"
+	"github.com/arturh85/pocketbase-go-generator/internal/pocketbase_api"
+)
+
+// schemaRuntime is emitted once per generated file and contains the checks of the generated VerifySchema
+const schemaRuntime = `
+// schemaField is a field the generated code relies on
+type schemaField struct {
+	Name     string
+	Type     string
+	Multiple bool
+	// Target is the collection name of relation fields
+	Target string
+}
+
+// stringFieldTypes are the field types read and written as plain strings, the generated code works with each of them
+var stringFieldTypes = []string{core.FieldTypeText, core.FieldTypeEditor, core.FieldTypeEmail, core.FieldTypeURL}
+
+func compatibleFieldType(expected string, actual string) bool {
+	return expected == actual || slices.Contains(stringFieldTypes, expected) && slices.Contains(stringFieldTypes, actual)
+}
+
+// verifyCollection checks that the collection exists with the type and fields the generated code relies on
+func verifyCollection(app core.App, name string, collectionType string, fields []schemaField) error {
+	collection, err := app.FindCollectionByNameOrId(name)
+	if err != nil { return fmt.Errorf("collection %s: %w", name, err) }
+	if collection.Type != collectionType {
+		return fmt.Errorf("collection %s: type is %s instead of %s", name, collection.Type, collectionType)
+	}
+
+	var errs []error
+	for _, expected := range fields {
+		field := collection.Fields.GetByName(expected.Name)
+		if field == nil {
+			errs = append(errs, fmt.Errorf("field %s.%s: missing", name, expected.Name))
+			continue
+		}
+		if !compatibleFieldType(expected.Type, field.Type()) {
+			errs = append(errs, fmt.Errorf("field %s.%s: type is %s instead of %s", name, expected.Name, field.Type(), expected.Type))
+			continue
+		}
+
+		multiValuer, ok := field.(core.MultiValuer)
+		if multiple := ok && multiValuer.IsMultiple(); multiple != expected.Multiple {
+			errs = append(errs, fmt.Errorf("field %s.%s: multiple is %t instead of %t", name, expected.Name, multiple, expected.Multiple))
+		}
+
+		if relation, ok := field.(*core.RelationField); ok && expected.Target != "" {
+			target, err := app.FindCollectionByNameOrId(relation.CollectionId)
+			if err != nil || target.Name != expected.Target {
+				errs = append(errs, fmt.Errorf("field %s.%s: does not reference %s", name, expected.Name, expected.Target))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+`
+
+func GetGoSchemaRuntime() string {
+	return schemaRuntime
+}
+
+func (property InterfaceProperty) getGoSchemaField() string {
+	target := ""
+	if property.RelationTarget != nil {
+		target = property.RelationTarget.Collection.Name
+	}
+
+	return fmt.Sprintf("\t\t\t{Name: %q, Type: %q, Multiple: %t, Target: %q},", property.Name, property.FieldType, property.IsArray, target)
+}
+
+/*
+example schema verification:
+
+	func VerifySchema(app core.App) error {
+	    return errors.Join(
+	        verifyCollection(app, CollectionPosts, "base", []schemaField{
+	            {Name: "title", Type: "text", Multiple: false, Target: ""},
+	        }),
+	    )
+	}
+*/
+func GetGoVerifySchema(collections []*CollectionWithProperties, generatorFlags *cmd.GeneratorFlags) string {
+	checks := make([]string, len(collections))
+
+	for i, collection := range collections {
+		fields := make([]string, len(collection.Properties))
+		for j, property := range collection.Properties {
+			fields[j] = property.getGoSchemaField()
+		}
+
+		checks[i] = fmt.Sprintf("\t\tverifyCollection(app, Collection%s, %q, []schemaField{\n%s\n\t\t}),", collection.GoName, collection.Collection.Type, strings.Join(fields, "\n"))
+	}
+
+	return fmt.Sprintf(`
+// VerifySchema checks that the collections and fields used by the generated code exist in app with a compatible
+// type and cardinality. Call it on startup to detect code generated from a different schema, all differences are
+// returned joined.
+func VerifySchema(app core.App) error {
+	return errors.Join(
+%s
+	)
+}
+`, strings.Join(checks, "\n"))
+}
+
+/*
+example schema snapshot:
+
+	const SchemaJSON = "[{\"id\":\"_pb_users_auth_\",\"name\":\"users\",...}]"
+
+	func ImportSchema(app core.App, deleteMissing bool) error
+*/
+func GetGoSchemaSnapshot(collections []*pocketbase_api.Collection, generatorFlags *cmd.GeneratorFlags) (string, error) {
+	raw := make([]json.RawMessage, len(collections))
+	for i, collection := range collections {
+		raw[i] = collection.Raw
+		if raw[i] == nil {
+			var err error
+			if raw[i], err = json.Marshal(collection); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	schema, err := json.Marshal(raw)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(`
+// SchemaJSON contains the collections the code was generated for, as exported by pocketbase
+const SchemaJSON = %q
+
+// ImportSchema imports the collections of SchemaJSON into app, other collections and fields are kept.
+//
+// deleteMissing is destructive: all other non-system collections and fields of app are deleted together with their
+// records. Only set it for databases which contain nothing but the collections of SchemaJSON.
+func ImportSchema(app core.App, deleteMissing bool) error {
+	return app.ImportCollectionsByMarshaledJSON([]byte(SchemaJSON), deleteMissing)
+}
+`, schema), nil
+}
@@ -0,0 +1,292 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arturh85/pocketbase-go-generator/internal/cmd"
+)
+
+// validationRuntime is emitted once per generated file and contains the checks used by all XxxStruct.Validate
+// methods, they mirror the validation of the pocketbase field types
+const validationRuntime = `
+// DefaultTextMax is the maximum length pocketbase uses for text fields without max
+const DefaultTextMax = 5000
+
+// DefaultFileMaxSize is the maximum file size pocketbase uses for file fields without maxSize
+const DefaultFileMaxSize = 5 << 20
+
+// DefaultEditorMaxSize and DefaultJSONMaxSize are the maximum sizes in bytes pocketbase uses for editor and json
+// fields without maxSize
+const (
+	DefaultEditorMaxSize = 5 << 20
+	DefaultJSONMaxSize   = 5 << 20
+)
+
+func deref[T any](value *T) T {
+	if value == nil {
+		var zero T
+		return zero
+	}
+	return *value
+}
+
+func limit(value float64) *float64 {
+	return &value
+}
+
+// nonEmpty returns values as strings without the empty ones, single select and relation fields use an empty string
+// for no value
+func nonEmpty[T ~string](values ...T) []string {
+	output := make([]string, 0, len(values))
+	for _, value := range values {
+		if value != "" { output = append(output, string(value)) }
+	}
+	return output
+}
+
+func validateRequired(required bool, empty bool) error {
+	if required && empty { return validation.ErrRequired }
+	return nil
+}
+
+func validateText(value string, required bool, min int, max int, pattern string) error {
+	if value == "" { return validateRequired(required, true) }
+
+	length := len([]rune(value))
+	if max == 0 { max = DefaultTextMax }
+	if min > 0 && length < min {
+		return validation.NewError("validation_min_text_constraint", "Must be at least {{.min}} character(s)").SetParams(map[string]any{"min": min})
+	}
+	if length > max {
+		return validation.NewError("validation_max_text_constraint", "Must be no more than {{.max}} character(s)").SetParams(map[string]any{"max": max})
+	}
+	if pattern != "" {
+		if match, _ := regexp.MatchString(pattern, value); !match {
+			return validation.NewError("validation_invalid_format", "Invalid value format")
+		}
+	}
+	return nil
+}
+
+func validateEditor(value string, required bool, maxSize int64) error {
+	if value == "" { return validateRequired(required, true) }
+
+	if maxSize <= 0 { maxSize = DefaultEditorMaxSize }
+	if int64(len(value)) > maxSize {
+		return validation.NewError("validation_content_size_limit", "The maximum allowed content size is {{.maxSize}} bytes").SetParams(map[string]any{"maxSize": maxSize})
+	}
+	return nil
+}
+
+// validateJSON checks the size of the json encoding of value, which is what pocketbase stores
+func validateJSON(value any, required bool, empty bool, maxSize int64) error {
+	if empty { return validateRequired(required, true) }
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return validation.NewError("validation_invalid_json", "Must be a valid json value")
+	}
+	if maxSize <= 0 { maxSize = DefaultJSONMaxSize }
+	if int64(len(raw)) > maxSize {
+		return validation.NewError("validation_json_size_limit", "The maximum allowed JSON size is {{.maxSize}} bytes").SetParams(map[string]any{"maxSize": maxSize})
+	}
+	return nil
+}
+
+func validateNumber(value float64, required bool, min *float64, max *float64, onlyInt bool) error {
+	if value == 0 { return validateRequired(required, true) }
+
+	if onlyInt && value != float64(int64(value)) {
+		return validation.NewError("validation_only_int_constraint", "Decimal numbers are not allowed")
+	}
+	if min != nil && value < *min {
+		return validation.NewError("validation_min_number_constraint", fmt.Sprintf("Must be larger than %f", *min))
+	}
+	if max != nil && value > *max {
+		return validation.NewError("validation_max_number_constraint", fmt.Sprintf("Must be less than %f", *max))
+	}
+	return nil
+}
+
+func validateEmail(value string, required bool, onlyDomains []string, exceptDomains []string) error {
+	if value == "" { return validateRequired(required, true) }
+
+	if err := is.EmailFormat.Validate(value); err != nil { return err }
+	domain := value[strings.LastIndex(value, "@")+1:]
+	if len(onlyDomains) > 0 && !slices.Contains(onlyDomains, domain) || slices.Contains(exceptDomains, domain) {
+		return validation.NewError("validation_email_domain_not_allowed", "Email domain is not allowed")
+	}
+	return nil
+}
+
+func validateURL(value string, required bool, onlyDomains []string, exceptDomains []string) error {
+	if value == "" { return validateRequired(required, true) }
+
+	if is.URL.Validate(value) != nil {
+		return validation.NewError("validation_invalid_url", "Must be a valid url")
+	}
+	parsed, _ := url.Parse(value)
+	if len(onlyDomains) > 0 && !slices.Contains(onlyDomains, parsed.Host) || slices.Contains(exceptDomains, parsed.Host) {
+		return validation.NewError("validation_url_domain_not_allowed", "Url domain is not allowed")
+	}
+	return nil
+}
+
+func validateDate(value string, required bool) error {
+	if value == "" { return validateRequired(required, true) }
+
+	if _, err := types.ParseDateTime(value); err != nil {
+		return validation.NewError("validation_invalid_date", "Must be a valid date")
+	}
+	return nil
+}
+
+// validateValues validates select, relation and file fields, allowed is empty if every value is allowed
+func validateValues(values []string, required bool, minSelect int, maxSelect int, allowed []string) error {
+	if len(values) == 0 { return validateRequired(required, true) }
+
+	if minSelect > 0 && len(values) < minSelect {
+		return validation.NewError("validation_not_enough_values", "Select at least {{.minSelect}}").SetParams(map[string]any{"minSelect": minSelect})
+	}
+	if len(values) > max(maxSelect, 1) {
+		return validation.NewError("validation_too_many_values", "Select no more than {{.maxSelect}}").SetParams(map[string]any{"maxSelect": max(maxSelect, 1)})
+	}
+	for _, value := range values {
+		if len(allowed) > 0 && !slices.Contains(allowed, value) {
+			return validation.NewError("validation_invalid_value", "Invalid value {{.value}}").SetParams(map[string]any{"value": value})
+		}
+	}
+	return nil
+}
+
+// validateUpload checks a file before it is uploaded, the mime type is derived from the file extension while
+// pocketbase checks the content
+func validateUpload(name string, size int64, maxSize int64, mimeTypes []string) error {
+	if maxSize <= 0 { maxSize = DefaultFileMaxSize }
+	if size > maxSize {
+		return validation.NewError("validation_file_size_limit", "Failed to upload {{.file}} - the maximum allowed file size is {{.maxSize}} bytes.").SetParams(map[string]any{"file": name, "maxSize": maxSize})
+	}
+
+	mimeType, _, _ := strings.Cut(mime.TypeByExtension(filepath.Ext(name)), ";")
+	if len(mimeTypes) > 0 && !slices.Contains(mimeTypes, mimeType) {
+		return validation.NewError("validation_invalid_mime_type", "{{.file}} mime type must be one of: {{.types}}.").SetParams(map[string]any{"file": name, "types": strings.Join(mimeTypes, ", ")})
+	}
+	return nil
+}
+`
+
+func GetGoValidationRuntime() string {
+	return validationRuntime
+}
+
+// getGoStructValue returns the expression of the property value in XxxStruct.Validate, pointers are dereferenced
+func (property InterfaceProperty) getGoStructValue() string {
+	value := "s." + property.GoName
+	if strings.HasPrefix(property.getGoTypeWithArray(propertyFlags{forceOptional: false, relationAsString: true}), "*") {
+		value = fmt.Sprintf("deref(%s)", value)
+	}
+
+	return value
+}
+
+func goFloatOption(value *float64) string {
+	if value == nil {
+		return "nil"
+	}
+
+	return fmt.Sprintf("limit(%v)", *value)
+}
+
+// getGoValidation returns the call validating the property in XxxStruct.Validate, empty if it is not validated
+func (property InterfaceProperty) getGoValidation() string {
+	value := property.getGoStructValue()
+	required := !property.Optional
+	constraints := property.Constraints
+
+	if property.IsReadOnly() {
+		return ""
+	}
+
+	switch property.FieldType {
+	case "text":
+		minLength, maxLength := 0, 0
+		if constraints.Min != nil {
+			minLength = int(*constraints.Min)
+		}
+		if constraints.Max != nil {
+			maxLength = int(*constraints.Max)
+		}
+		return fmt.Sprintf("validateText(%s, %t, %d, %d, %q)", value, required, minLength, maxLength, constraints.Pattern)
+	case "editor":
+		return fmt.Sprintf("validateEditor(%s, %t, %d)", value, required, constraints.MaxSize)
+	case "number":
+		return fmt.Sprintf("validateNumber(float64(%s), %t, %s, %s, %t)", value, required, goFloatOption(constraints.Min), goFloatOption(constraints.Max), constraints.OnlyInt)
+	case "bool":
+		// a required bool has to be true
+		return fmt.Sprintf("validateRequired(%t, !%s)", required, value)
+	case "email":
+		return fmt.Sprintf("validateEmail(%s, %t, %#v, %#v)", value, required, constraints.OnlyDomains, constraints.ExceptDomains)
+	case "url":
+		return fmt.Sprintf("validateURL(%s, %t, %#v, %#v)", value, required, constraints.OnlyDomains, constraints.ExceptDomains)
+	case "date":
+		return fmt.Sprintf("validateDate(%s, %t)", value, required)
+	case "json":
+		return fmt.Sprintf("validateJSON(%[1]s, %[2]t, len(%[1]s) == 0, %[3]d)", value, required, constraints.MaxSize)
+	case "select", "relation", "file":
+		values := fmt.Sprintf("nonEmpty(%s)", value)
+		if property.IsArray {
+			values = fmt.Sprintf("nonEmpty(%s...)", value)
+		}
+
+		var allowed []string
+		if property.FieldType == "select" {
+			allowed = property.Data.([]string)
+		}
+
+		return fmt.Sprintf("validateValues(%s, %t, %d, %d, %#v)", values, required, constraints.MinSelect, property.MaxSelect, allowed)
+	}
+
+	return ""
+}
+
+/*
+example validation:
+
+	func (s PostsStruct) Validate() error {
+	    errs := validation.Errors{}
+	    if err := validateText(s.Title, true, 3, 100, ""); err != nil { errs["title"] = err }
+	    ...
+	}
+*/
+func (collection CollectionWithProperties) GetGoValidation(generatorFlags *cmd.GeneratorFlags) string {
+	var checks []string
+	var uploads []string
+
+	for _, property := range collection.Properties {
+		if check := property.getGoValidation(); check != "" {
+			checks = append(checks, fmt.Sprintf("\tif err := %s; err != nil { errs[%q] = err }", check, property.Name))
+		}
+
+		if property.FieldType == "file" {
+			uploads = append(uploads, fmt.Sprintf(`
+// %[1]s_Validate%[2]sUpload checks the size and mime type of a file before it is uploaded to %[3]s
+func %[1]s_Validate%[2]sUpload(name string, size int64) error {
+	return validateUpload(name, size, %[4]d, %#[5]v)
+}
+`, collection.GoName, property.GoName, property.Name, property.Constraints.MaxSize, property.MimeTypes))
+		}
+	}
+
+	return fmt.Sprintf(`
+// Validate checks the field options of the %[2]s collection without a database, errors are returned as
+// *ValidationError. Relations are not checked for existence and files are only counted, use the
+// %[1]s_ValidateXxxUpload functions for uploads.
+func (s %[1]sStruct) Validate() error {
+	errs := validation.Errors{}
+%[3]s
+	if len(errs) == 0 { return nil }
+	return newRecordError(Collection%[1]s, errs)
+}
+%[4]s`, collection.GoName, collection.Collection.Name, strings.Join(checks, "\n"), strings.Join(uploads, ""))
+}
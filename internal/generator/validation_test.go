@@ -0,0 +1,36 @@
+package generator
+
+import (
+	"testing"
+)
+
+func TestGetGoValidation(t *testing.T) {
+	minLength, maxLength, maxNumber := 3.0, 100.0, 5.0
+
+	tests := []struct {
+		name     string
+		property InterfaceProperty
+		expected string
+	}{
+		{"text", InterfaceProperty{Name: "title", FieldType: "text", Constraints: FieldConstraints{Min: &minLength, Max: &maxLength, Pattern: "^[a-z]+$"}}, `validateText(s.Title, true, 3, 100, "^[a-z]+$")`},
+		{"optional text", InterfaceProperty{Name: "title", FieldType: "text", Optional: true}, `validateText(deref(s.Title), false, 0, 0, "")`},
+		{"editor", InterfaceProperty{Name: "body", FieldType: "editor", Constraints: FieldConstraints{MaxSize: 1024}}, "validateEditor(s.Body, true, 1024)"},
+		{"json", InterfaceProperty{Name: "meta", Type: IptJson, FieldType: "json", Constraints: FieldConstraints{MaxSize: 2048}}, "validateJSON(s.Meta, true, len(s.Meta) == 0, 2048)"},
+		{"number", InterfaceProperty{Name: "rating", Type: IptNumber, FieldType: "number", Constraints: FieldConstraints{Max: &maxNumber, OnlyInt: true}}, "validateNumber(float64(s.Rating), true, nil, limit(5), true)"},
+		{"bool", InterfaceProperty{Name: "accepted", Type: IptBoolean, FieldType: "bool"}, "validateRequired(true, !s.Accepted)"},
+		{"email", InterfaceProperty{Name: "contact", FieldType: "email", Optional: true, Constraints: FieldConstraints{OnlyDomains: []string{"example.com"}}}, `validateEmail(deref(s.Contact), false, []string{"example.com"}, []string(nil))`},
+		{"single select", InterfaceProperty{Name: "status", Type: IptEnum, FieldType: "select", Data: []string{"draft"}}, `validateValues(nonEmpty(s.Status), true, 0, 0, []string{"draft"})`},
+		{"multiple relation", InterfaceProperty{Name: "tags", Type: IptRelation, FieldType: "relation", IsArray: true, MaxSelect: 5, Constraints: FieldConstraints{MinSelect: 1}}, "validateValues(nonEmpty(s.Tags...), true, 1, 5, []string(nil))"},
+		{"primary key", InterfaceProperty{Name: "id", FieldType: "text", PrimaryKey: true}, ""},
+		{"autodate", InterfaceProperty{Name: "created", Type: IptDate, FieldType: "autodate"}, ""},
+	}
+
+	for _, test := range tests {
+		property := test.property
+		ResolveIdentifiers([]*CollectionWithProperties{newTestCollection("posts", "base", &property)}, "")
+
+		if validation := property.getGoValidation(); validation != test.expected {
+			t.Errorf("%s: expected %s, got %s", test.name, test.expected, validation)
+		}
+	}
+}
@@ -1,17 +1,33 @@
 package interpreter
 
 import (
+	"slices"
+	"strings"
+
+	"github.com/arturh85/pocketbase-go-generator/internal/cmd"
 	"github.com/arturh85/pocketbase-go-generator/internal/generator"
 	"github.com/arturh85/pocketbase-go-generator/internal/pocketbase_api"
+	"github.com/pocketbase/pocketbase/tools/dbutils"
+	"github.com/rs/zerolog/log"
 )
 
-func InterpretCollections(collections []*pocketbase_api.Collection, allCollections []pocketbase_api.Collection) []*generator.CollectionWithProperties {
+// authCoreFields are the fields of auth collections which core.Record already has typed getters and setters for
+var authCoreFields = []string{"email", "emailVisibility", "verified"}
+
+func InterpretCollections(collections []*pocketbase_api.Collection, allCollections []pocketbase_api.Collection, generatorFlags *cmd.GeneratorFlags) []*generator.CollectionWithProperties {
 	output := make([]*generator.CollectionWithProperties, len(collections))
 
 	for i, collection := range collections {
 		output[i] = InterpretCollection(collection, allCollections)
 	}
 
+	linkRelations(output)
+	markRecursiveRelations(output)
+
+	for _, rename := range generator.ResolveIdentifiers(output, generatorFlags.IdentifierSuffix) {
+		log.Warn().Msg(rename.String())
+	}
+
 	return output
 }
 
@@ -28,6 +44,8 @@ func InterpretCollection(collection *pocketbase_api.Collection, allCollections [
 		output.Properties = append(output.Properties, InterpretProperty(field, collection, allCollections))
 	}
 
+	output.Indexes = InterpretIndexes(collection.Indexes, output.Properties)
+
 	return output
 }
 
@@ -37,6 +55,25 @@ func InterpretProperty(field pocketbase_api.CollectionField, collection *pocketb
 		CollectionName: collection.Name,
 		Type:           generator.GetInterfacePropertyType(field.Type),
 		Optional:       !field.Required,
+		FieldType:      field.Type,
+		MaxSelect:      field.MaxSelect,
+		Thumbs:         field.Thumbs,
+		MimeTypes:      field.MimeTypes,
+		System:         field.System,
+		PrimaryKey:     field.PrimaryKey,
+		OnCreate:       field.OnCreate,
+		OnUpdate:       field.OnUpdate,
+		CoreAccessors:  collection.Type == "auth" && slices.Contains(authCoreFields, field.Name),
+		Constraints: generator.FieldConstraints{
+			Min:           interpretNumberOption(field.Min),
+			Max:           interpretNumberOption(field.Max),
+			Pattern:       field.Pattern,
+			OnlyInt:       field.OnlyInt,
+			MinSelect:     field.MinSelect,
+			MaxSize:       field.MaxSize,
+			ExceptDomains: field.ExceptDomains,
+			OnlyDomains:   field.OnlyDomains,
+		},
 	}
 
 	if output.Type == generator.IptEnum || output.Type == generator.IptRelation || output.Type == generator.IptFile {
@@ -66,3 +103,46 @@ func InterpretProperty(field pocketbase_api.CollectionField, collection *pocketb
 
 	return output
 }
+
+func interpretNumberOption(option pocketbase_api.NumberOption) *float64 {
+	if !option.Valid {
+		return nil
+	}
+
+	return &option.Value
+}
+
+// InterpretIndexes parses the CREATE INDEX statements of a collection, indexes with columns which are no single
+// value properties (expressions, hidden, json or multiple value fields) can not be used for finders and are skipped
+func InterpretIndexes(indexes []string, properties []*generator.InterfaceProperty) []*generator.CollectionIndex {
+	var output []*generator.CollectionIndex
+
+	for _, rawIndex := range indexes {
+		index := dbutils.ParseIndex(rawIndex)
+		if !index.IsValid() {
+			continue
+		}
+
+		collectionIndex := &generator.CollectionIndex{
+			Name:   index.IndexName,
+			Unique: index.Unique,
+		}
+
+		for _, column := range index.Columns {
+			for _, property := range properties {
+				if strings.EqualFold(property.Name, column.Name) && !property.IsArray && property.Type != generator.IptJson {
+					collectionIndex.Properties = append(collectionIndex.Properties, property)
+					break
+				}
+			}
+		}
+
+		if len(collectionIndex.Properties) != len(index.Columns) {
+			continue
+		}
+
+		output = append(output, collectionIndex)
+	}
+
+	return output
+}
@@ -0,0 +1,112 @@
+package interpreter
+
+import (
+	"github.com/arturh85/pocketbase-go-generator/internal/generator"
+	"github.com/arturh85/pocketbase-go-generator/internal/pocketbase_api"
+	"github.com/rs/zerolog/log"
+)
+
+// AddRelatedCollections extends the selection with every collection reachable through relation fields,
+// so that the generated code does not reference types of collections which were not selected.
+func AddRelatedCollections(selectedCollections []*pocketbase_api.Collection, allCollections []pocketbase_api.Collection) []*pocketbase_api.Collection {
+	output := append([]*pocketbase_api.Collection{}, selectedCollections...)
+
+	included := make(map[string]bool, len(selectedCollections))
+	for _, collection := range selectedCollections {
+		included[collection.Id] = true
+	}
+
+	for i := 0; i < len(output); i++ {
+		for _, field := range output[i].Fields {
+			if field.Hidden || generator.GetInterfacePropertyType(field.Type) != generator.IptRelation || included[field.CollectionId] {
+				continue
+			}
+
+			for j := range allCollections {
+				if allCollections[j].Id == field.CollectionId {
+					log.Info().Msgf("Including collection %s referenced by %s.%s", allCollections[j].Name, output[i].Name, field.Name)
+
+					included[field.CollectionId] = true
+					output = append(output, &allCollections[j])
+					break
+				}
+			}
+		}
+	}
+
+	return output
+}
+
+// linkRelations points every relation property to the interpreted collection it references and registers it as
+// back relation on that collection.
+// Relations to collections which are not part of the output keep a nil RelationTarget and are degraded to
+// plain ID strings, every affected field is reported in the log.
+func linkRelations(collections []*generator.CollectionWithProperties) {
+	byName := make(map[string]*generator.CollectionWithProperties, len(collections))
+
+	for _, collection := range collections {
+		byName[collection.Collection.Name] = collection
+	}
+
+	for _, collection := range collections {
+		for _, property := range collection.Properties {
+			if property.Type != generator.IptRelation {
+				continue
+			}
+
+			relationTo, ok := property.Data.(string)
+			if !ok {
+				log.Warn().Msgf("Relation %s.%s references an unknown collection, using plain ID strings", collection.Collection.Name, property.Name)
+				continue
+			}
+
+			property.RelationTarget = byName[relationTo]
+
+			if property.RelationTarget == nil {
+				log.Warn().Msgf("Relation %s.%s references unselected collection %s, using plain ID strings", collection.Collection.Name, property.Name, relationTo)
+				continue
+			}
+
+			property.RelationTarget.BackRelations = append(property.RelationTarget.BackRelations, property)
+		}
+	}
+}
+
+// markRecursiveRelations flags relations which would embed a struct into itself (directly or through other
+// collections) in the generated Expanded structs. Only required single relations are embedded by value,
+// optional and multiple relations are already pointers or slices and break the cycle on their own.
+func markRecursiveRelations(collections []*generator.CollectionWithProperties) {
+	for _, collection := range collections {
+		for _, property := range collection.Properties {
+			if !isEmbeddedByValue(property) {
+				continue
+			}
+
+			property.RecursiveRelation = reachesCollection(property.RelationTarget, collection, map[*generator.CollectionWithProperties]bool{})
+		}
+	}
+}
+
+func isEmbeddedByValue(property *generator.InterfaceProperty) bool {
+	return property.Type == generator.IptRelation && property.RelationTarget != nil && !property.Optional && !property.IsArray
+}
+
+func reachesCollection(from *generator.CollectionWithProperties, target *generator.CollectionWithProperties, visited map[*generator.CollectionWithProperties]bool) bool {
+	if from == target {
+		return true
+	}
+
+	if visited[from] {
+		return false
+	}
+
+	visited[from] = true
+
+	for _, property := range from.Properties {
+		if isEmbeddedByValue(property) && reachesCollection(property.RelationTarget, target, visited) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,150 @@
+package interpreter
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/arturh85/pocketbase-go-generator/internal/cmd"
+	"github.com/arturh85/pocketbase-go-generator/internal/generator"
+	"github.com/arturh85/pocketbase-go-generator/internal/pocketbase_api"
+)
+
+func loadTestCollections(t *testing.T, path string) []*generator.CollectionWithProperties {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := &pocketbase_api.CollectionsResponse{}
+	if err := json.Unmarshal(data, response); err != nil {
+		t.Fatal(err)
+	}
+
+	selected := make([]*pocketbase_api.Collection, len(response.Items))
+	for i := range response.Items {
+		selected[i] = &response.Items[i]
+	}
+
+	return InterpretCollections(selected, response.Items, &cmd.GeneratorFlags{})
+}
+
+func newRelatedCollection(id string, relations ...string) pocketbase_api.Collection {
+	collection := pocketbase_api.Collection{Id: id, Name: id}
+	for _, target := range relations {
+		collection.Fields = append(collection.Fields, pocketbase_api.CollectionField{Name: "to_" + target, Type: "relation", CollectionId: target})
+	}
+
+	return collection
+}
+
+func TestAddRelatedCollections(t *testing.T) {
+	hidden := newRelatedCollection("hidden")
+	hidden.Fields = append(hidden.Fields, pocketbase_api.CollectionField{Name: "secret", Type: "relation", CollectionId: "c", Hidden: true})
+
+	all := []pocketbase_api.Collection{
+		newRelatedCollection("a", "b"),
+		newRelatedCollection("b", "c"),
+		newRelatedCollection("c"),
+		newRelatedCollection("x", "y", "x"),
+		newRelatedCollection("y", "x", "c"),
+		newRelatedCollection("unrelated"),
+		hidden,
+	}
+
+	tests := []struct {
+		selected []string
+		expected []string
+	}{
+		{[]string{"a"}, []string{"a", "b", "c"}},
+		{[]string{"c", "a"}, []string{"c", "a", "b"}},
+		{[]string{"x"}, []string{"x", "y", "c"}},
+		{[]string{"y", "x"}, []string{"y", "x", "c"}},
+		{[]string{"hidden"}, []string{"hidden"}},
+	}
+
+	for _, test := range tests {
+		var selected []*pocketbase_api.Collection
+		for _, name := range test.selected {
+			for i := range all {
+				if all[i].Name == name {
+					selected = append(selected, &all[i])
+				}
+			}
+		}
+
+		output := AddRelatedCollections(selected, all)
+
+		names := make([]string, len(output))
+		for i, collection := range output {
+			names[i] = collection.Name
+		}
+
+		if !slices.Equal(names, test.expected) {
+			t.Errorf("%v: expected %v, got %v", test.selected, test.expected, names)
+		}
+	}
+}
+
+// TestMarkRecursiveRelations checks every relation of the test collections, only the relations closing a cycle are
+// marked recursive
+func TestMarkRecursiveRelations(t *testing.T) {
+	collections := loadTestCollections(t, "testdata/cyclic_collections.json")
+
+	recursive := []string{"categories.parent", "authors.favorite_book", "books.author"}
+	relations := 0
+
+	for _, collection := range collections {
+		for _, property := range collection.Properties {
+			if property.Type != generator.IptRelation {
+				continue
+			}
+			relations++
+
+			name := collection.Collection.Name + "." + property.Name
+			if property.RelationTarget == nil {
+				t.Errorf("%s: relation target was not linked", name)
+			}
+
+			if expected := slices.Contains(recursive, name); property.RecursiveRelation != expected {
+				t.Errorf("%s: expected RecursiveRelation %v, got %v", name, expected, property.RecursiveRelation)
+			}
+		}
+	}
+
+	// categories.parent, categories.children, authors.favorite_book, books.author, books.publisher, books.category
+	if relations != 6 {
+		t.Errorf("expected 6 relations, got %d", relations)
+	}
+}
+
+func TestCyclicStructsTypeCheck(t *testing.T) {
+	collections := loadTestCollections(t, "testdata/cyclic_collections.json")
+	generatorFlags := &cmd.GeneratorFlags{}
+
+	structs := make([]string, len(collections))
+	for i, collection := range collections {
+		structs[i] = collection.GetGoStruct(generatorFlags)
+	}
+
+	source := "package collections\n\n" + strings.Join(structs, "\n\n")
+
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, "collections.go", source, 0)
+	if err != nil {
+		t.Fatalf("generated structs do not parse: %v\n%s", err, source)
+	}
+
+	config := types.Config{}
+	if _, err := config.Check("collections", fileSet, []*ast.File{file}, nil); err != nil {
+		t.Fatalf("generated structs do not type check: %v\n%s", err, source)
+	}
+}
@@ -0,0 +1,376 @@
+package migration
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/arturh85/pocketbase-go-generator/internal/pocketbase_api"
+)
+
+// ErrNoChanges is returned by Generate if the collections match the snapshot
+var ErrNoChanges = errors.New("no collection changes")
+
+// ignoredKeys are collection keys which are never migrated, the timestamps change on every save
+var ignoredKeys = []string{"fields", "created", "updated"}
+
+type collectionMap = map[string]any
+
+// readCollections returns the collections of snapshot and collections as maps
+func readCollections(snapshot []byte, collections []pocketbase_api.Collection) ([]collectionMap, []collectionMap, error) {
+	var oldCollections []collectionMap
+	if err := json.Unmarshal(snapshot, &oldCollections); err != nil {
+		return nil, nil, fmt.Errorf("could not read snapshot: %w", err)
+	}
+
+	newCollections := make([]collectionMap, len(collections))
+	for i, collection := range collections {
+		raw := collection.Raw
+		if raw == nil {
+			var err error
+			if raw, err = json.Marshal(collection); err != nil {
+				return nil, nil, err
+			}
+		}
+		if err := json.Unmarshal(raw, &newCollections[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return oldCollections, newCollections, nil
+}
+
+// MissingCollections returns the names of the collections of snapshot which are not part of collections
+func MissingCollections(snapshot []byte, collections []pocketbase_api.Collection) ([]string, error) {
+	oldCollections, newCollections, err := readCollections(snapshot, collections)
+	if err != nil {
+		return nil, err
+	}
+
+	var output []string
+	for _, oldCollection := range oldCollections {
+		if findById(newCollections, oldCollection["id"]) == nil {
+			output = append(output, fmt.Sprint(oldCollection["name"]))
+		}
+	}
+
+	return output, nil
+}
+
+// Generate returns a pocketbase migration in package packageName which migrates the collections of snapshot to
+// collections on up and back on down. Collections and fields are matched by id, so renames are migrated as such.
+// Collections of snapshot which are missing in collections are only deleted if deleteMissing is set.
+func Generate(packageName string, snapshot []byte, collections []pocketbase_api.Collection, deleteMissing bool) (string, error) {
+	oldCollections, newCollections, err := readCollections(snapshot, collections)
+	if err != nil {
+		return "", err
+	}
+
+	for _, collection := range slices.Concat(oldCollections, newCollections) {
+		// like the pocketbase migrations, oauth2 providers are skipped as they contain secrets
+		if oauth2, ok := collection["oauth2"].(map[string]any); ok {
+			delete(oauth2, "providers")
+		}
+	}
+
+	var up, down []string
+
+	var created []collectionMap
+	for _, newCollection := range newCollections {
+		if findById(oldCollections, newCollection["id"]) == nil {
+			created = append(created, newCollection)
+		}
+	}
+
+	if len(created) > 0 {
+		create, err := createBlock(created)
+		if err != nil {
+			return "", err
+		}
+
+		up = append(up, create)
+		down = append(down, deleteBlock(created))
+	}
+
+	for _, newCollection := range newCollections {
+		oldCollection := findById(oldCollections, newCollection["id"])
+		if oldCollection == nil {
+			continue
+		}
+
+		upUpdate, err := updateBlock(oldCollection, newCollection)
+		if err != nil {
+			return "", err
+		}
+		if upUpdate == "" {
+			continue
+		}
+
+		downUpdate, err := updateBlock(newCollection, oldCollection)
+		if err != nil {
+			return "", err
+		}
+
+		up = append(up, upUpdate)
+		down = append(down, downUpdate)
+	}
+
+	var deleted []collectionMap
+	for _, oldCollection := range oldCollections {
+		if deleteMissing && findById(newCollections, oldCollection["id"]) == nil {
+			deleted = append(deleted, oldCollection)
+		}
+	}
+
+	if len(deleted) > 0 {
+		create, err := createBlock(deleted)
+		if err != nil {
+			return "", err
+		}
+
+		up = append(up, deleteBlock(deleted))
+		down = append(down, create)
+	}
+
+	if len(up) == 0 {
+		return "", ErrNoChanges
+	}
+
+	// down reverts the changes in reverse order
+	slices.Reverse(down)
+
+	imports := ""
+	if strings.Contains(strings.Join(slices.Concat(up, down), ""), "json.Unmarshal(") {
+		imports = "\n\t\"encoding/json\"\n"
+	}
+
+	source := fmt.Sprintf(`package %s
+
+import (%s
+	"github.com/pocketbase/pocketbase/core"
+	m "github.com/pocketbase/pocketbase/migrations"
+)
+
+func init() {
+	m.Register(func(app core.App) error {
+%s
+		return nil
+	}, func(app core.App) error {
+%s
+		return nil
+	})
+}
+`, packageName, imports, strings.TrimPrefix(strings.Join(up, "\n"), "\n"), strings.TrimPrefix(strings.Join(down, "\n"), "\n"))
+
+	formatted, err := format.Source([]byte(source))
+	if err != nil {
+		return "", err
+	}
+
+	return string(formatted), nil
+}
+
+func findById(collections []collectionMap, id any) collectionMap {
+	for _, collection := range collections {
+		if collection["id"] == id {
+			return collection
+		}
+	}
+
+	return nil
+}
+
+func collectionNames(collections []collectionMap) string {
+	names := make([]string, len(collections))
+	for i, collection := range collections {
+		names[i] = fmt.Sprint(collection["name"])
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// createBlock imports all collections at once, which saves them before validating their relations, so they may
+// reference each other
+func createBlock(collections []collectionMap) (string, error) {
+	data := make([]collectionMap, len(collections))
+	for i, collection := range collections {
+		data[i] = make(collectionMap, len(collection))
+		for key, value := range collection {
+			if key != "created" && key != "updated" {
+				data[i][key] = value
+			}
+		}
+	}
+
+	raw, err := marshalBacktick(data)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(`
+		// create collections %s
+		if err := app.ImportCollectionsByMarshaledJSON([]byte(%s), false); err != nil {
+			return err
+		}
+`, collectionNames(collections), raw), nil
+}
+
+// deleteBlock deletes all collections without checking the relations between them, relations of other
+// collections to them are removed by the update blocks before
+func deleteBlock(collections []collectionMap) string {
+	ids := make([]string, len(collections))
+	for i, collection := range collections {
+		ids[i] = fmt.Sprintf("%q", collection["id"])
+	}
+
+	return fmt.Sprintf(`
+		// delete collections %s
+		for _, id := range []string{%s} {
+			collection, err := app.FindCollectionByNameOrId(id)
+			if err != nil {
+				return err
+			}
+			collection.IntegrityChecks(false)
+			if err := app.Delete(collection); err != nil {
+				return err
+			}
+		}
+`, collectionNames(collections), strings.Join(ids, ", "))
+}
+
+// updateBlock returns the statements changing oldCollection to newCollection, empty if they are equal
+func updateBlock(oldCollection collectionMap, newCollection collectionMap) (string, error) {
+	var parts []string
+
+	if diff := diffMaps(oldCollection, newCollection, ignoredKeys...); len(diff) > 0 {
+		raw, err := marshalBacktick(diff)
+		if err != nil {
+			return "", err
+		}
+
+		parts = append(parts, fmt.Sprintf("// update collection data\nif err := json.Unmarshal([]byte(%s), &collection); err != nil {\nreturn err\n}", raw))
+	}
+
+	// the fields of views are derived from their query with new ids on every save
+	if newCollection["type"] == "view" {
+		oldCollection, newCollection = maps.Clone(oldCollection), maps.Clone(newCollection)
+		delete(oldCollection, "fields")
+		delete(newCollection, "fields")
+	}
+
+	oldFields := fieldMaps(oldCollection)
+	newFields := fieldMaps(newCollection)
+
+	for _, oldField := range oldFields {
+		if findById(newFields, oldField["id"]) == nil {
+			parts = append(parts, fmt.Sprintf("// remove field %s\ncollection.Fields.RemoveById(%q)", oldField["name"], oldField["id"]))
+		}
+	}
+
+	for i, newField := range newFields {
+		oldField := findById(oldFields, newField["id"])
+		if oldField != nil && reflect.DeepEqual(oldField, newField) {
+			continue
+		}
+
+		raw, err := marshalBacktick(newField)
+		if err != nil {
+			return "", err
+		}
+
+		comment := "add field"
+		if oldField != nil {
+			comment = "update field"
+		}
+
+		parts = append(parts, fmt.Sprintf("// %s %s\nif err := collection.Fields.AddMarshaledJSONAt(%d, []byte(%s)); err != nil {\nreturn err\n}", comment, newField["name"], i, raw))
+	}
+
+	if len(parts) == 0 {
+		return "", nil
+	}
+
+	return fmt.Sprintf(`
+		// update collection %s
+		{
+			collection, err := app.FindCollectionByNameOrId(%q)
+			if err != nil {
+				return err
+			}
+
+%s
+
+			if err := app.Save(collection); err != nil {
+				return err
+			}
+		}
+`, newCollection["name"], oldCollection["id"], strings.Join(parts, "\n\n")), nil
+}
+
+func fieldMaps(collection collectionMap) []collectionMap {
+	fields, _ := collection["fields"].([]any)
+
+	output := make([]collectionMap, 0, len(fields))
+	for _, field := range fields {
+		if fieldMap, ok := field.(map[string]any); ok {
+			output = append(output, fieldMap)
+		}
+	}
+
+	return output
+}
+
+// diffMaps returns the keys of newMap which differ from oldMap, removed keys are nil and changed maps only contain
+// their changed keys
+func diffMaps(oldMap map[string]any, newMap map[string]any, excludeKeys ...string) map[string]any {
+	diff := map[string]any{}
+
+	for key, newValue := range newMap {
+		if slices.Contains(excludeKeys, key) {
+			continue
+		}
+
+		oldValue, ok := oldMap[key]
+		if ok && reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+
+		oldValueMap, oldIsMap := oldValue.(map[string]any)
+		newValueMap, newIsMap := newValue.(map[string]any)
+		if oldIsMap && newIsMap {
+			diff[key] = diffMaps(oldValueMap, newValueMap)
+		} else {
+			diff[key] = newValue
+		}
+	}
+
+	for key := range oldMap {
+		if _, ok := newMap[key]; !ok && !slices.Contains(excludeKeys, key) {
+			diff[key] = nil
+		}
+	}
+
+	return diff
+}
+
+// marshalBacktick returns value as indented json in a go raw string literal
+func marshalBacktick(value any) (string, error) {
+	var buffer bytes.Buffer
+
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "\t")
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+
+	raw := strings.TrimSpace(buffer.String())
+
+	return "`" + strings.ReplaceAll(raw, "`", "` + \"`\" + `") + "`", nil
+}
@@ -0,0 +1,207 @@
+package migration
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/arturh85/pocketbase-go-generator/internal/pocketbase_api"
+)
+
+const testSnapshot = `[
+	{"id": "pbc_posts", "name": "posts", "type": "base", "listRule": null, "created": "2024-01-01 00:00:00.000Z", "fields": [
+		{"id": "f_title", "name": "title", "type": "text", "max": 100},
+		{"id": "f_slug", "name": "slug", "type": "text", "max": 0}
+	]},
+	{"id": "pbc_old", "name": "old", "type": "base", "fields": []}
+]`
+
+// testCurrent changes posts, creates new and drops old of testSnapshot
+const testCurrent = `[
+	{"id": "pbc_posts", "name": "posts", "type": "base", "listRule": "", "created": "2025-01-01 00:00:00.000Z", "fields": [
+		{"id": "f_title", "name": "title", "type": "text", "max": 200},
+		{"id": "f_body", "name": "body", "type": "editor"}
+	]},
+	{"id": "pbc_new", "name": "new", "type": "base", "indexes": ["CREATE INDEX `+"`idx`"+` ON new (id)"], "fields": []}
+]`
+
+func loadCurrent(t *testing.T, data string) []pocketbase_api.Collection {
+	t.Helper()
+
+	var collections []pocketbase_api.Collection
+	if err := json.Unmarshal([]byte(data), &collections); err != nil {
+		t.Fatal(err)
+	}
+
+	return collections
+}
+
+func TestGenerate(t *testing.T) {
+	source, err := Generate("migrations", []byte(testSnapshot), loadCurrent(t, testCurrent), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	up, down, _ := strings.Cut(source, "}, func(app core.App) error {")
+
+	expected := []struct {
+		part     string
+		contains string
+	}{
+		{up, "// create collections new"},
+		{up, "// update collection data"},
+		{up, `"listRule": ""`},
+		{up, `collection.Fields.RemoveById("f_slug")`},
+		{up, "// update field title\n\t\t\tif err := collection.Fields.AddMarshaledJSONAt(0,"},
+		{up, "// add field body\n\t\t\tif err := collection.Fields.AddMarshaledJSONAt(1,"},
+		{up, "// delete collections old"},
+		{down, `"listRule": null`},
+		{down, "// add field slug\n\t\t\tif err := collection.Fields.AddMarshaledJSONAt(1,"},
+		{down, `collection.Fields.RemoveById("f_body")`},
+		{down, "// create collections old"},
+		{down, "// delete collections new"},
+	}
+
+	for _, test := range expected {
+		if !strings.Contains(test.part, test.contains) {
+			t.Errorf("expected migration to contain %q:\n%s", test.contains, source)
+		}
+	}
+
+	if strings.Contains(source, "2025-01-01") {
+		t.Errorf("expected timestamps to be skipped:\n%s", source)
+	}
+
+	if strings.Index(down, "// create collections old") > strings.Index(down, "// delete collections new") {
+		t.Errorf("expected down to revert in reverse order:\n%s", source)
+	}
+}
+
+// TestGenerateMissing only deletes the collections missing in the current selection if requested
+func TestGenerateMissing(t *testing.T) {
+	current := loadCurrent(t, testCurrent)
+
+	missing, err := MissingCollections([]byte(testSnapshot), current)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(missing, []string{"old"}) {
+		t.Errorf("expected old to be missing, got %v", missing)
+	}
+
+	for _, deleteMissing := range []bool{false, true} {
+		source, err := Generate("migrations", []byte(testSnapshot), current, deleteMissing)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		up, down, _ := strings.Cut(source, "}, func(app core.App) error {")
+
+		if strings.Contains(up, "// delete collections old") != deleteMissing || strings.Contains(down, "// create collections old") != deleteMissing {
+			t.Errorf("delete missing %t: expected old to be deleted %t:\n%s", deleteMissing, deleteMissing, source)
+		}
+	}
+}
+
+// TestGeneratedMigrationRuns applies the migration of testSnapshot to testCurrent to a pocketbase test app and
+// reverts it, the checks are in testdata/migration_test.go
+func TestGeneratedMigrationRuns(t *testing.T) {
+	if testing.Short() {
+		t.Skip("the generated migration builds pocketbase")
+	}
+
+	source, err := Generate("migrations", []byte(testSnapshot), loadCurrent(t, testCurrent), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	test, err := os.ReadFile("testdata/migration_test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the package has to be inside the module to resolve the pocketbase dependencies, directories starting with _
+	// are ignored by ./...
+	dir, err := os.MkdirTemp(".", "_generated")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	files := map[string][]byte{
+		"1_collections_diff.go": []byte(source),
+		"migration_test.go":     test,
+		"snapshot.json":         []byte(testSnapshot),
+	}
+
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	output, err := exec.Command("go", "test", "./"+dir).CombinedOutput()
+	if err != nil {
+		t.Fatalf("generated migration does not pass the tests: %v\n%s", err, output)
+	}
+}
+
+func TestGenerateReferencingCollections(t *testing.T) {
+	current := loadCurrent(t, testSnapshot[:len(testSnapshot)-1]+`,
+		{"id": "pbc_authors", "name": "authors", "type": "base", "fields": [
+			{"id": "f_books", "name": "books", "type": "relation", "collectionId": "pbc_books", "maxSelect": 99}
+		]},
+		{"id": "pbc_books", "name": "books", "type": "base", "fields": [
+			{"id": "f_author", "name": "author", "type": "relation", "collectionId": "pbc_authors", "maxSelect": 1}
+		]}
+	]`)
+
+	source, err := Generate("migrations", []byte(testSnapshot), current, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	up, down, _ := strings.Cut(source, "}, func(app core.App) error {")
+
+	// collections which reference each other can only be created together
+	if strings.Count(up, "app.ImportCollectionsByMarshaledJSON(") != 1 || !strings.Contains(up, "// create collections authors, books") {
+		t.Errorf("expected authors and books to be imported at once:\n%s", source)
+	}
+
+	if !strings.Contains(down, "// delete collections authors, books\n\t\tfor _, id := range []string{\"pbc_authors\", \"pbc_books\"} {") {
+		t.Errorf("expected authors and books to be deleted together:\n%s", source)
+	}
+
+	if !strings.Contains(down, "collection.IntegrityChecks(false)") {
+		t.Errorf("expected the delete to skip the relation checks:\n%s", source)
+	}
+}
+
+func TestGenerateNoChanges(t *testing.T) {
+	_, err := Generate("migrations", []byte(testSnapshot), loadCurrent(t, testSnapshot), false)
+	if !errors.Is(err, ErrNoChanges) {
+		t.Fatalf("expected ErrNoChanges, got %v", err)
+	}
+}
+
+func TestReadSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "collections.go")
+	source := "package collections\n\nconst SchemaJSON = \"[{\\\"id\\\":\\\"pbc_posts\\\"}]\"\n"
+	if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot, err := ReadSnapshot(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(snapshot) != `[{"id":"pbc_posts"}]` {
+		t.Errorf("unexpected snapshot %s", snapshot)
+	}
+}
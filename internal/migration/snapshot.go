@@ -0,0 +1,51 @@
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+)
+
+// snapshotConst is the name of the constant generated with --embed-schema
+const snapshotConst = "SchemaJSON"
+
+// ReadSnapshot returns the collections json embedded in a generated file
+func ReadSnapshot(path string) ([]byte, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.CONST {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			valueSpec := spec.(*ast.ValueSpec)
+			for i, name := range valueSpec.Names {
+				if name.Name != snapshotConst || i >= len(valueSpec.Values) {
+					continue
+				}
+
+				literal, ok := valueSpec.Values[i].(*ast.BasicLit)
+				if !ok || literal.Kind != token.STRING {
+					return nil, fmt.Errorf("%s in %s is no string literal", snapshotConst, path)
+				}
+
+				snapshot, err := strconv.Unquote(literal.Value)
+				if err != nil {
+					return nil, err
+				}
+
+				return []byte(snapshot), nil
+			}
+		}
+	}
+
+	return nil, errors.New(path + " contains no " + snapshotConst + ", generate it with --embed-schema")
+}
@@ -0,0 +1,97 @@
+package migrations
+
+import (
+	"os"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	_ "github.com/pocketbase/pocketbase/migrations"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+// this test is copied next to the migration generated by TestGeneratedMigrationRuns
+
+// expected describes the fields of a collection after a migration, the missing collections are nil
+type expected map[string]map[string]int
+
+func checkCollections(t *testing.T, app core.App, label string, listRule *string, want expected) {
+	for name, fields := range want {
+		collection, err := app.FindCollectionByNameOrId(name)
+		if fields == nil {
+			if err == nil {
+				t.Errorf("%s: expected collection %s to be deleted", label, name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: expected collection %s: %v", label, name, err)
+			continue
+		}
+
+		for field, max := range fields {
+			value := collection.Fields.GetByName(field)
+			if value == nil {
+				t.Errorf("%s: expected field %s.%s", label, name, field)
+				continue
+			}
+			if text, ok := value.(*core.TextField); ok && text.Max != max {
+				t.Errorf("%s: expected max %d of %s.%s, got %d", label, max, name, field, text.Max)
+			}
+		}
+		if len(collection.Fields) != len(fields)+1 {
+			t.Errorf("%s: expected %d fields in %s, got %d", label, len(fields)+1, name, len(collection.Fields))
+		}
+
+		if name == "posts" && (listRule == nil) != (collection.ListRule == nil) {
+			t.Errorf("%s: expected list rule %v of posts, got %v", label, listRule, collection.ListRule)
+		}
+	}
+}
+
+func TestMigration(t *testing.T) {
+	var migration *core.Migration
+	for _, item := range core.AppMigrations.Items() {
+		if item.File == "1_collections_diff.go" {
+			migration = item
+		}
+	}
+	if migration == nil {
+		t.Fatal("expected the generated migration to be registered")
+	}
+	// the test app must not apply the generated migration by itself
+	core.AppMigrations = core.MigrationsList{}
+
+	app, err := tests.NewTestApp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer app.Cleanup()
+
+	snapshot, err := os.ReadFile("snapshot.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.ImportCollectionsByMarshaledJSON(snapshot, false); err != nil {
+		t.Fatal(err)
+	}
+
+	empty := ""
+
+	if err := migration.Up(app); err != nil {
+		t.Fatal(err)
+	}
+	checkCollections(t, app, "up", &empty, expected{
+		"posts": {"title": 200, "body": 0},
+		"new":   {},
+		"old":   nil,
+	})
+
+	if err := migration.Down(app); err != nil {
+		t.Fatal(err)
+	}
+	checkCollections(t, app, "down", nil, expected{
+		"posts": {"title": 100, "slug": 0},
+		"new":   nil,
+		"old":   {},
+	})
+}
@@ -16,15 +16,64 @@ type CollectionField struct {
 	MaxSelect    int      `json:"maxSelect"`
 	Required     bool     `json:"required"`
 	Hidden       bool     `json:"hidden"`
+	System       bool     `json:"system"`
+	PrimaryKey   bool     `json:"primaryKey"`
+	OnCreate     bool     `json:"onCreate"`
+	OnUpdate     bool     `json:"onUpdate"`
 	Values       []string `json:"values"`
+	Thumbs       []string `json:"thumbs"`
+	MimeTypes    []string `json:"mimeTypes"`
+
+	Min           NumberOption `json:"min"`
+	Max           NumberOption `json:"max"`
+	Pattern       string       `json:"pattern"`
+	OnlyInt       bool         `json:"onlyInt"`
+	MinSelect     int          `json:"minSelect"`
+	MaxSize       int64        `json:"maxSize"`
+	ExceptDomains []string     `json:"exceptDomains"`
+	OnlyDomains   []string     `json:"onlyDomains"`
+}
+
+// NumberOption is an optional numeric field option like min and max of text and number fields. Date fields use the
+// same keys for dates, those are ignored.
+type NumberOption struct {
+	Value float64
+	Valid bool
+}
+
+func (option *NumberOption) UnmarshalJSON(data []byte) error {
+	var value *float64
+	if err := json.Unmarshal(data, &value); err != nil || value == nil {
+		*option = NumberOption{}
+		return nil
+	}
+
+	*option = NumberOption{Value: *value, Valid: true}
+	return nil
 }
 
 type Collection struct {
-	Id     string            `json:"id"`
-	Name   string            `json:"name"`
-	Type   string            `json:"type"`
-	System bool              `json:"system"`
-	Fields []CollectionField `json:"fields"`
+	Id      string            `json:"id"`
+	Name    string            `json:"name"`
+	Type    string            `json:"type"`
+	System  bool              `json:"system"`
+	Fields  []CollectionField `json:"fields"`
+	Indexes []string          `json:"indexes"`
+
+	ViewQuery string `json:"viewQuery"`
+
+	// Raw is the complete json of the collection as returned by pocketbase, it is embedded with --embed-schema
+	Raw json.RawMessage `json:"-"`
+}
+
+func (collection *Collection) UnmarshalJSON(data []byte) error {
+	type plainCollection Collection
+	if err := json.Unmarshal(data, (*plainCollection)(collection)); err != nil {
+		return err
+	}
+
+	collection.Raw = append(json.RawMessage(nil), data...)
+	return nil
 }
 
 type CollectionsResponse struct {
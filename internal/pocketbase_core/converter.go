@@ -1,6 +1,8 @@
 package pocketbase_core
 
 import (
+	"encoding/json"
+
 	"github.com/arturh85/pocketbase-go-generator/internal/pocketbase_api"
 	"github.com/pocketbase/pocketbase/core"
 )
@@ -16,12 +18,20 @@ func convertPBCollections(pbCollections []*core.Collection) []pocketbase_api.Col
 }
 
 func convertPBCollection(pbCollection *core.Collection) pocketbase_api.Collection {
+	raw, _ := json.Marshal(pbCollection)
+
 	return pocketbase_api.Collection{
 		Id:     pbCollection.Id,
 		Name:   pbCollection.Name,
 		Type:   pbCollection.Type,
 		System: pbCollection.System,
 		Fields: convertPBFields(pbCollection.Fields),
+
+		Indexes: pbCollection.Indexes,
+
+		ViewQuery: pbCollection.ViewQuery,
+
+		Raw: raw,
 	}
 }
 
@@ -41,23 +51,39 @@ func convertPBField(pbField core.Field) pocketbase_api.CollectionField {
 		Name:   pbField.GetName(),
 		Type:   pbField.Type(),
 		Hidden: pbField.GetHidden(),
+		System: pbField.GetSystem(),
 	}
 
 	switch v := pbField.(type) {
 	case *core.TextField:
 		field.Required = v.Required
+		field.PrimaryKey = v.PrimaryKey
+		field.Min = pocketbase_api.NumberOption{Value: float64(v.Min), Valid: true}
+		field.Max = pocketbase_api.NumberOption{Value: float64(v.Max), Valid: true}
+		field.Pattern = v.Pattern
 	case *core.EditorField:
 		field.Required = v.Required
+		field.MaxSize = v.MaxSize
 	case *core.NumberField:
 		field.Required = v.Required
+		field.Min = convertPBNumberOption(v.Min)
+		field.Max = convertPBNumberOption(v.Max)
+		field.OnlyInt = v.OnlyInt
 	case *core.BoolField:
 		field.Required = v.Required
 	case *core.EmailField:
 		field.Required = v.Required
+		field.ExceptDomains = v.ExceptDomains
+		field.OnlyDomains = v.OnlyDomains
 	case *core.URLField:
 		field.Required = v.Required
+		field.ExceptDomains = v.ExceptDomains
+		field.OnlyDomains = v.OnlyDomains
 	case *core.DateField:
 		field.Required = v.Required
+	case *core.AutodateField:
+		field.OnCreate = v.OnCreate
+		field.OnUpdate = v.OnUpdate
 	case *core.SelectField:
 		field.MaxSelect = v.MaxSelect
 		field.Required = v.Required
@@ -65,11 +91,26 @@ func convertPBField(pbField core.Field) pocketbase_api.CollectionField {
 	case *core.FileField:
 		field.MaxSelect = v.MaxSelect
 		field.Required = v.Required
+		field.Thumbs = v.Thumbs
+		field.MimeTypes = v.MimeTypes
+		field.MaxSize = v.MaxSize
 	case *core.RelationField:
 		field.MaxSelect = v.MaxSelect
+		field.MinSelect = v.MinSelect
 		field.Required = v.Required
 		field.CollectionId = v.CollectionId
+	case *core.JSONField:
+		field.Required = v.Required
+		field.MaxSize = v.MaxSize
 	}
 
 	return field
 }
+
+func convertPBNumberOption(value *float64) pocketbase_api.NumberOption {
+	if value == nil {
+		return pocketbase_api.NumberOption{}
+	}
+
+	return pocketbase_api.NumberOption{Value: *value, Valid: true}
+}
@@ -14,4 +14,11 @@ func RegisterCommand(app *pocketbase.PocketBase) {
 			log.Fatal().Err(err).Msg("Could not process file generation")
 		}
 	}))
+
+	app.RootCmd.AddCommand(cmd.GetGenerateMigrationCommand(true, func(cmd *cobra.Command, args []string, generatorFlags *cmd.GeneratorFlags) {
+		err := processMigrationGeneration(app, generatorFlags)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Could not process migration generation")
+		}
+	}))
 }
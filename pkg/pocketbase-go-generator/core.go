@@ -23,3 +23,16 @@ func processFileGeneration(app *pocketbase.PocketBase, generatorFlags *cmd.Gener
 
 	return nil
 }
+
+func processMigrationGeneration(app *pocketbase.PocketBase, generatorFlags *cmd.GeneratorFlags) error {
+	collections, err := pocketbase_core.GetCollections(app)
+	if err != nil {
+		return err
+	}
+
+	selectedCollections := forms.GetSelectedCollections(generatorFlags, collections.Items)
+
+	core.ProcessMigration(selectedCollections, collections.Items, generatorFlags)
+
+	return nil
+}
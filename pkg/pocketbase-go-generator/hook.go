@@ -10,8 +10,15 @@ type GeneratorOptions struct {
 	AllCollections     bool
 	CollectionsInclude []string
 	CollectionsExclude []string
+	CollectionsRelated bool
 
 	Output string
+
+	// IdentifierSuffix is appended to generated identifiers which collide, defaults to "Field"
+	IdentifierSuffix string
+
+	// EmbedSchema embeds the collections json as SchemaJSON and generates ImportSchema
+	EmbedSchema bool
 }
 
 func RegisterHook(app *pocketbase.PocketBase, options *GeneratorOptions) {
@@ -19,8 +26,12 @@ func RegisterHook(app *pocketbase.PocketBase, options *GeneratorOptions) {
 		AllCollections:     options.AllCollections,
 		CollectionsInclude: options.CollectionsInclude,
 		CollectionsExclude: options.CollectionsExclude,
+		CollectionsRelated: options.CollectionsRelated,
 
 		Output: options.Output,
+
+		IdentifierSuffix: options.IdentifierSuffix,
+		EmbedSchema:      options.EmbedSchema,
 	}
 
 	app.OnCollectionAfterCreateSuccess().BindFunc(func(e *pbcore.CollectionEvent) error {